@@ -0,0 +1,11 @@
+//go:build !windows
+
+package main
+
+import "syscall"
+
+// execProcess replaces the current process image with path, preserving PID,
+// stdio, signals, argv, and environment. It only returns on error.
+func execProcess(path string, argv []string, env []string) error {
+	return syscall.Exec(path, argv, env)
+}
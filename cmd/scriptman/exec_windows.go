@@ -0,0 +1,28 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+)
+
+// execProcess has no true process-replacement primitive on Windows, so it
+// starts path as a child, waits for it, and exits with its status code. PID
+// is not preserved, but stdio, signals, argv, and environment are.
+func execProcess(path string, argv []string, env []string) error {
+	proc, err := os.StartProcess(path, argv, &os.ProcAttr{
+		Files: []*os.File{os.Stdin, os.Stdout, os.Stderr},
+		Env:   env,
+	})
+	if err != nil {
+		return err
+	}
+
+	state, err := proc.Wait()
+	if err != nil {
+		return err
+	}
+
+	os.Exit(state.ExitCode())
+	return nil
+}
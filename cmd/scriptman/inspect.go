@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/sfkleach/scriptman/pkg/interpreter"
+	"github.com/spf13/cobra"
+)
+
+// newInspectCommand creates the inspect command: it runs the same
+// interpreter-detection logic as install against a local script file and
+// prints the resulting decision, without fetching, verifying, linting, or
+// wrapping anything.
+func newInspectCommand() *cobra.Command {
+	var jsonOutput bool
+	var explicitInterpreter string
+	var trustShebang bool
+
+	cmd := &cobra.Command{
+		Use:   "inspect <script>",
+		Short: "Show how scriptman would resolve a script's interpreter",
+		Long: `Inspect runs DetermineInterpreterChoices against a local script file and
+prints the resulting decision tree, without fetching, verifying, linting, or
+wrapping anything.
+
+With --json, the full decision (every candidate choice, its source, and why
+it was or wasn't picked) is printed to stdout as a single JSON object, so
+external orchestrators (installers, package managers) can drive scriptman
+programmatically instead of parsing human-readable text.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+
+			decision := interpreter.NewDecisionInput(path, content, explicitInterpreter, trustShebang).DetermineInterpreterChoices()
+
+			if jsonOutput {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(decision)
+			}
+
+			return printDecision(decision)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the full decision as JSON instead of human-readable text")
+	cmd.Flags().StringVar(&explicitInterpreter, "interpreter", "", "Explicit interpreter, as with 'install --interpreter'")
+	cmd.Flags().BoolVar(&trustShebang, "trust-shebang", false, "Trust the script's shebang line without consistency checks")
+
+	return cmd
+}
+
+// printDecision prints a DecisionResult for a human: the error, or each
+// candidate choice alongside its reason.
+func printDecision(decision interpreter.DecisionResult) error {
+	if decision.Error != nil {
+		return decision.Error
+	}
+
+	if len(decision.Choices) == 1 {
+		choice := decision.Choices[0]
+		fmt.Printf("Interpreter: %s\n", choice.Interpreter)
+		fmt.Printf("Source:      %s\n", choice.Source)
+		fmt.Printf("Reason:      %s\n", choice.Reason)
+		if choice.RequiresPrompt {
+			fmt.Println("Requires confirmation: yes")
+		}
+		return nil
+	}
+
+	fmt.Println("Multiple interpreter options:")
+	for i, choice := range decision.Choices {
+		fmt.Printf("  %d. %s (%s) - %s\n", i+1, choice.Interpreter, choice.Source, choice.Reason)
+	}
+	return nil
+}
@@ -5,6 +5,10 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/sfkleach/scriptman/pkg/config"
+	"github.com/sfkleach/scriptman/pkg/install"
+	"github.com/sfkleach/scriptman/pkg/platform"
+	"github.com/sfkleach/scriptman/pkg/registry"
 	"github.com/sfkleach/scriptman/pkg/version"
 	"github.com/spf13/cobra"
 )
@@ -31,10 +35,12 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&versionFlag, "version", false, "Print version information")
 
 	rootCmd.AddCommand(version.NewVersionCommand())
-	rootCmd.AddCommand(newInstallCommand())
+	rootCmd.AddCommand(install.NewInstallCommand())
+	rootCmd.AddCommand(install.NewSyncCommand())
 	rootCmd.AddCommand(newInfoCommand())
 	rootCmd.AddCommand(newListCommand())
 	rootCmd.AddCommand(newCheckCommand())
+	rootCmd.AddCommand(newInspectCommand())
 	rootCmd.AddCommand(newUpdateCommand())
 	rootCmd.AddCommand(newRemoveCommand())
 }
@@ -54,9 +60,41 @@ func main() {
 	}
 }
 
-// runScript handles runner mode when invoked via a hardlink.
+// runScript handles runner mode when invoked via a hardlinked/symlinked name
+// other than "scriptman" (see install --wrapper-mode exec). It resolves the
+// interpreter and script straight from the registry and execs in place,
+// preserving PID, stdio, signals, argv, and environment.
 func runScript(name string) {
-	// Find our own location.
+	reg, err := registry.Load(config.GetDefaultRegistryPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "scriptman: cannot load registry: %v\n", err)
+		os.Exit(1)
+	}
+
+	script, err := reg.Get(name)
+	if err != nil {
+		runShellWrapperFallback(name)
+		return
+	}
+
+	if script.RootDir != "" {
+		if err := os.Chdir(script.RootDir); err != nil {
+			fmt.Fprintf(os.Stderr, "scriptman: cannot chdir to %s: %v\n", script.RootDir, err)
+			os.Exit(1)
+		}
+	}
+
+	cmdPath, argv := platform.MakeShebangCommand(script.Interpreter, script.Args, script.LocalScript, os.Args[1:])
+	if err := execProcess(cmdPath, argv, os.Environ()); err != nil {
+		fmt.Fprintf(os.Stderr, "scriptman: failed to exec %s: %v\n", cmdPath, err)
+		os.Exit(1)
+	}
+}
+
+// runShellWrapperFallback supports the legacy companion-shell-script layout,
+// for wrappers installed before the registry carried enough information to
+// exec directly, or restored from a registry-less backup.
+func runShellWrapperFallback(name string) {
 	self, err := os.Executable()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "scriptman: cannot determine executable path: %v\n", err)
@@ -64,33 +102,21 @@ func runScript(name string) {
 	}
 	dir := filepath.Dir(self)
 
-	// Look for companion shell script.
 	shScript := filepath.Join(dir, name+".sh")
 	if _, err := os.Stat(shScript); err == nil {
-		// Execute the shell script.
-		// TODO: Use syscall.Exec for proper process replacement.
-		fmt.Fprintf(os.Stderr, "scriptman: would exec %s\n", shScript)
-		os.Exit(0)
+		argv := append([]string{shScript}, os.Args[1:]...)
+		if err := execProcess(shScript, argv, os.Environ()); err != nil {
+			fmt.Fprintf(os.Stderr, "scriptman: failed to exec %s: %v\n", shScript, err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	// No script found.
 	fmt.Fprintf(os.Stderr, "scriptman: no dispatch found for '%s'\n", name)
-	fmt.Fprintf(os.Stderr, "scriptman: looked for %s\n", shScript)
+	fmt.Fprintf(os.Stderr, "scriptman: looked for %s in the registry and %s on disk\n", name, shScript)
 	os.Exit(1)
 }
 
-// newInstallCommand creates the install command stub.
-func newInstallCommand() *cobra.Command {
-	return &cobra.Command{
-		Use:   "install",
-		Short: "Install a script from a GitHub repository",
-		Long:  "Install a script from a GitHub repository (TBD).",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println("install command: TBD")
-		},
-	}
-}
-
 // newInfoCommand creates the info command stub.
 func newInfoCommand() *cobra.Command {
 	return &cobra.Command{
@@ -115,26 +141,30 @@ func newListCommand() *cobra.Command {
 	}
 }
 
-// newCheckCommand creates the check command stub.
+// newCheckCommand creates the check command.
 func newCheckCommand() *cobra.Command {
 	return &cobra.Command{
-		Use:   "check",
+		Use:   "check [NAME...]",
 		Short: "Check for updates to installed scripts",
-		Long:  "Check for updates to installed scripts (TBD).",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println("check command: TBD")
+		Long: `Compare each installed script's pinned commit (or every installed script,
+if no names are given) against its source's latest commit or release. Never
+modifies the registry or lockfile; run 'scriptman update' to apply.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return install.RunCheck(args)
 		},
 	}
 }
 
-// newUpdateCommand creates the update command stub.
+// newUpdateCommand creates the update command.
 func newUpdateCommand() *cobra.Command {
 	return &cobra.Command{
-		Use:   "update",
+		Use:   "update [NAME...]",
 		Short: "Update installed scripts",
-		Long:  "Update installed scripts to the latest versions (TBD).",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println("update command: TBD")
+		Long: `Reinstall each installed script (or every installed script, if no names
+are given) whose source has a commit or release newer than the one
+currently pinned.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return install.RunUpdate(args)
 		},
 	}
 }
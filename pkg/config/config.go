@@ -1,35 +1,83 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Supported on-disk config formats, selected by file extension.
+const (
+	FormatJSON = "json"
+	FormatTOML = "toml"
 )
 
 // Config represents the scriptman configuration.
 type Config struct {
-	BinDir    string `json:"bin_dir"`
-	ScriptDir string `json:"script_dir"`
+	BinDir            string `json:"bin_dir" toml:"bin_dir"`
+	ScriptDir         string `json:"script_dir" toml:"script_dir"`
+	ScriptPermissions uint32 `json:"script_permissions,omitempty" toml:"script_permissions,omitempty"`
+
+	// Format records which on-disk format this Config was loaded from (or
+	// should be saved as), so Save writes back in the same format. It is
+	// never itself persisted.
+	Format string `json:"-" toml:"-"`
 }
 
-// Load reads the configuration from disk. Returns default config if file doesn't exist.
+// Load reads the configuration from disk. It looks for config.json first,
+// then config.toml, and returns the default configuration if neither
+// exists. See LoadReader for the format-agnostic parsing logic.
 func Load() (*Config, error) {
-	configPath := GetConfigPath()
+	jsonPath := GetConfigPath()
+	tomlPath := configPathForFormat(FormatTOML)
+
+	path, format := jsonPath, FormatJSON
+	if _, err := os.Stat(jsonPath); os.IsNotExist(err) {
+		if _, err := os.Stat(tomlPath); err == nil {
+			path, format = tomlPath, FormatTOML
+		} else {
+			// Neither file exists: return default configuration.
+			return GetDefaultConfig()
+		}
+	}
 
-	data, err := os.ReadFile(configPath)
-	if os.IsNotExist(err) {
-		// Return default configuration.
-		return GetDefaultConfig()
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
+	defer f.Close()
+
+	return LoadReader(f, format)
+}
+
+// LoadReader parses a configuration from r in the given format ("json" or
+// "toml"), applying defaults for any fields left empty.
+func LoadReader(r io.Reader, format string) (*Config, error) {
+	data, err := io.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
 
 	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config: %w", err)
+	switch format {
+	case FormatTOML:
+		if err := toml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+	case FormatJSON:
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
 	}
+	cfg.Format = format
 
 	// Defensive check: ensure paths are not empty, use defaults if needed.
 	if cfg.BinDir == "" {
@@ -50,22 +98,40 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
-// Save writes the configuration to disk.
+// Save writes the configuration to disk, in whatever format it was loaded
+// from (defaulting to JSON for a Config built from scratch).
 func (c *Config) Save() error {
-	configPath := GetConfigPath()
+	format := c.Format
+	if format == "" {
+		format = FormatJSON
+	}
+	path := configPathForFormat(format)
 
 	// Ensure parent directory exists.
-	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	data, err := json.MarshalIndent(c, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+	var data []byte
+	switch format {
+	case FormatTOML:
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(c); err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		data = buf.Bytes()
+	case FormatJSON:
+		marshaled, err := json.MarshalIndent(c, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		data = marshaled
+	default:
+		return fmt.Errorf("unsupported config format %q", format)
 	}
 
 	// Write with proper permissions.
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	if err := os.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
@@ -83,12 +149,14 @@ func GetDefaultConfig() (*Config, error) {
 		return nil, err
 	}
 	return &Config{
-		BinDir:    binDir,
-		ScriptDir: scriptDir,
+		BinDir:            binDir,
+		ScriptDir:         scriptDir,
+		ScriptPermissions: 0600,
+		Format:            FormatJSON,
 	}, nil
 }
 
-// GetConfigPath returns the path to the configuration file.
+// GetConfigPath returns the path to the JSON configuration file.
 func GetConfigPath() string {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -97,6 +165,16 @@ func GetConfigPath() string {
 	return filepath.Join(home, ".config", "scriptman", "config.json")
 }
 
+// configPathForFormat returns the configuration file path for the given
+// format, swapping the extension of GetConfigPath accordingly.
+func configPathForFormat(format string) string {
+	path := GetConfigPath()
+	if format == FormatTOML {
+		return strings.TrimSuffix(path, filepath.Ext(path)) + ".toml"
+	}
+	return path
+}
+
 // getDefaultBinDir returns the default directory for wrappers.
 func getDefaultBinDir() (string, error) {
 	home, err := os.UserHomeDir()
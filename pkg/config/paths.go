@@ -34,3 +34,46 @@ func GetDefaultRegistryPath() string {
 	}
 	return filepath.Join(home, ".config", "scriptman", "registry.json")
 }
+
+// GetDefaultLockfilePath returns the default path for the lockfile.
+func GetDefaultLockfilePath() string {
+	// Use ~/.config/scriptman/scriptman.lock as default.
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".", ".scriptman", "scriptman.lock")
+	}
+	return filepath.Join(home, ".config", "scriptman", "scriptman.lock")
+}
+
+// GetDefaultTrustedKeysPath returns the default path for the trusted signing
+// keys file consulted by `install --verify`.
+func GetDefaultTrustedKeysPath() string {
+	// Use ~/.config/scriptman/trusted_keys as default.
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".", ".scriptman", "trusted_keys")
+	}
+	return filepath.Join(home, ".config", "scriptman", "trusted_keys")
+}
+
+// GetDefaultInterpreterPolicyPath returns the default path for the
+// ssh_config-style interpreter selection rules file.
+func GetDefaultInterpreterPolicyPath() string {
+	// Use ~/.config/scriptman/interpreters.conf as default.
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".", ".scriptman", "interpreters.conf")
+	}
+	return filepath.Join(home, ".config", "scriptman", "interpreters.conf")
+}
+
+// GetDefaultInterpreterCachePath returns the default path for the cache of
+// probed interpreter versions used to resolve shebang version constraints.
+func GetDefaultInterpreterCachePath() string {
+	// Use ~/.cache/scriptman/interpreters.json as default.
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".", ".scriptman", "cache", "interpreters.json")
+	}
+	return filepath.Join(home, ".cache", "scriptman", "interpreters.json")
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PermissionIssue is a single directory found to be group- or
+// world-writable without the sticky bit set.
+type PermissionIssue struct {
+	Path string
+	Mode os.FileMode
+}
+
+// PermissionError reports every unsafe ancestor directory Validate found, so
+// callers can print one actionable fix per path instead of a single opaque
+// error. install and sync surface it by refusing to proceed.
+type PermissionError struct {
+	Issues []PermissionIssue
+}
+
+func (e *PermissionError) Error() string {
+	lines := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		lines[i] = fmt.Sprintf("%s is group/other-writable (mode %04o); fix with: chmod go-w %s", issue.Path, issue.Mode.Perm(), issue.Path)
+	}
+	return "unsafe directory permissions:\n" + strings.Join(lines, "\n")
+}
+
+// Validate checks that the configuration is safe to act on. It rejects
+// ScriptPermissions with the group or other write bits set, and - because
+// scriptman generates executable wrappers into BinDir - it also audits
+// BinDir, ScriptDir, and their ancestors up to $HOME for group/other write
+// access without the sticky bit, mirroring the hygiene checks OpenSSH
+// performs on ~/.ssh. A writable ancestor is a privilege-escalation vector
+// and is refused outright rather than merely warned about.
+func (c *Config) Validate() error {
+	if c.ScriptPermissions&0022 != 0 {
+		return fmt.Errorf("invalid script permissions 0%o: group and other write bits must not be set", c.ScriptPermissions)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var issues []PermissionIssue
+	for _, dir := range []string{c.BinDir, c.ScriptDir} {
+		for _, issue := range auditDirAncestors(dir, home) {
+			if seen[issue.Path] {
+				continue
+			}
+			seen[issue.Path] = true
+			issues = append(issues, issue)
+		}
+	}
+
+	if len(issues) > 0 {
+		return &PermissionError{Issues: issues}
+	}
+
+	return nil
+}
+
+// auditDirAncestors walks dir and its ancestors up to (and including)
+// stopAt, reporting every existing directory that is group- or
+// world-writable without the sticky bit. Directories that don't exist yet
+// are skipped rather than treated as errors.
+func auditDirAncestors(dir, stopAt string) []PermissionIssue {
+	var issues []PermissionIssue
+
+	stopAt = filepath.Clean(stopAt)
+	current := filepath.Clean(dir)
+
+	for {
+		if info, err := os.Stat(current); err == nil && info.IsDir() {
+			mode := info.Mode()
+			if mode&0022 != 0 && mode&os.ModeSticky == 0 {
+				issues = append(issues, PermissionIssue{Path: current, Mode: mode})
+			}
+		}
+
+		parent := filepath.Dir(current)
+		if current == stopAt || parent == current {
+			break
+		}
+		current = parent
+	}
+
+	return issues
+}
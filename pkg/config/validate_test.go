@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAuditDirAncestors(t *testing.T) {
+	home := t.TempDir()
+	safeDir := filepath.Join(home, "safe", "bin")
+	if err := os.MkdirAll(safeDir, 0700); err != nil {
+		t.Fatalf("MkdirAll error: %v", err)
+	}
+
+	t.Run("AllSafe", func(t *testing.T) {
+		issues := auditDirAncestors(safeDir, home)
+		if len(issues) != 0 {
+			t.Errorf("expected no issues, got %v", issues)
+		}
+	})
+
+	t.Run("WorldWritableAncestor", func(t *testing.T) {
+		unsafeDir := filepath.Join(home, "unsafe", "bin")
+		if err := os.MkdirAll(unsafeDir, 0755); err != nil {
+			t.Fatalf("MkdirAll error: %v", err)
+		}
+		if err := os.Chmod(filepath.Join(home, "unsafe"), 0777); err != nil {
+			t.Fatalf("Chmod error: %v", err)
+		}
+
+		issues := auditDirAncestors(unsafeDir, home)
+		if len(issues) != 1 {
+			t.Fatalf("expected 1 issue, got %d: %v", len(issues), issues)
+		}
+		if issues[0].Path != filepath.Join(home, "unsafe") {
+			t.Errorf("issue path = %q, want %q", issues[0].Path, filepath.Join(home, "unsafe"))
+		}
+	})
+
+	t.Run("StickyBitExempt", func(t *testing.T) {
+		stickyDir := filepath.Join(home, "sticky", "bin")
+		if err := os.MkdirAll(stickyDir, 0755); err != nil {
+			t.Fatalf("MkdirAll error: %v", err)
+		}
+		if err := os.Chmod(filepath.Join(home, "sticky"), 0777|os.ModeSticky); err != nil {
+			t.Fatalf("Chmod error: %v", err)
+		}
+
+		issues := auditDirAncestors(stickyDir, home)
+		if len(issues) != 0 {
+			t.Errorf("expected sticky-bit directory to be exempt, got %v", issues)
+		}
+	})
+
+	t.Run("MissingDirSkipped", func(t *testing.T) {
+		issues := auditDirAncestors(filepath.Join(home, "does-not-exist", "bin"), home)
+		if len(issues) != 0 {
+			t.Errorf("expected missing directories to be skipped, got %v", issues)
+		}
+	})
+}
+
+func TestValidateReportsUnsafeAncestor(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	binDir := filepath.Join(home, "bin")
+	if err := os.MkdirAll(binDir, 0777); err != nil {
+		t.Fatalf("MkdirAll error: %v", err)
+	}
+	if err := os.Chmod(binDir, 0777); err != nil {
+		t.Fatalf("Chmod error: %v", err)
+	}
+
+	cfg := &Config{BinDir: binDir, ScriptDir: filepath.Join(home, "scripts"), ScriptPermissions: 0600}
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatalf("expected Validate to reject a world-writable BinDir")
+	}
+
+	permErr, ok := err.(*PermissionError)
+	if !ok {
+		t.Fatalf("expected *PermissionError, got %T: %v", err, err)
+	}
+	if len(permErr.Issues) != 1 || permErr.Issues[0].Path != binDir {
+		t.Errorf("got issues %v, want one issue for %q", permErr.Issues, binDir)
+	}
+}
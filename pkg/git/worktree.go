@@ -0,0 +1,135 @@
+// Package git wraps the `git` CLI to fetch a script together with the
+// sibling files and data it needs, by cloning the owning repository into a
+// temporary worktree rather than downloading a single raw file.
+package git
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Worktree is a shallow, single-ref checkout of a repository in a temporary
+// directory. Callers must call Cleanup when done with it.
+type Worktree struct {
+	Dir string
+}
+
+// Clone shallow-fetches cloneURL at ref into a new temporary directory and
+// checks it out. If ref is empty, the remote's default branch is used.
+// ref may be a branch, tag, or commit SHA.
+func Clone(cloneURL, ref string) (*Worktree, error) {
+	dir, err := os.MkdirTemp("", "scriptman-worktree-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp worktree directory: %w", err)
+	}
+
+	wt := &Worktree{Dir: dir}
+
+	for _, args := range [][]string{
+		{"init", "--quiet", dir},
+		{"-C", dir, "remote", "add", "origin", cloneURL},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			wt.Cleanup()
+			return nil, fmt.Errorf("git %v failed: %w\n%s", args, err, out)
+		}
+	}
+
+	fetchRef := ref
+	if fetchRef == "" {
+		fetchRef = "HEAD"
+	}
+	if out, err := exec.Command("git", "-C", dir, "fetch", "--depth", "1", "origin", fetchRef).CombinedOutput(); err != nil {
+		wt.Cleanup()
+		return nil, fmt.Errorf("git fetch %s failed: %w\n%s", fetchRef, err, out)
+	}
+	if out, err := exec.Command("git", "-C", dir, "checkout", "--quiet", "FETCH_HEAD").CombinedOutput(); err != nil {
+		wt.Cleanup()
+		return nil, fmt.Errorf("git checkout failed: %w\n%s", err, out)
+	}
+
+	return wt, nil
+}
+
+// HeadCommit returns the resolved commit SHA checked out in the worktree.
+func HeadCommit(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse HEAD failed: %w", err)
+	}
+	return string(trimNewline(out)), nil
+}
+
+// Cleanup removes the worktree's temporary directory.
+func (w *Worktree) Cleanup() error {
+	if w.Dir == "" {
+		return nil
+	}
+	if err := os.RemoveAll(w.Dir); err != nil {
+		return fmt.Errorf("failed to remove worktree %s: %w", w.Dir, err)
+	}
+	return nil
+}
+
+// CopyTree recursively copies srcDir into dstDir, preserving the source
+// directory's tree, used to move a cloned worktree into scriptman's
+// versioned script storage once the clone succeeds.
+func CopyTree(srcDir, dstDir string) error {
+	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return os.MkdirAll(dstDir, 0755)
+		}
+		if filepath.Base(rel) == ".git" {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		dstPath := filepath.Join(dstDir, rel)
+		if info.IsDir() {
+			return os.MkdirAll(dstPath, 0755)
+		}
+
+		return copyFile(path, dstPath, info.Mode())
+	})
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+func trimNewline(b []byte) []byte {
+	for len(b) > 0 && (b[len(b)-1] == '\n' || b[len(b)-1] == '\r') {
+		b = b[:len(b)-1]
+	}
+	return b
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrimNewline(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "abc123\n", want: "abc123"},
+		{in: "abc123\r\n", want: "abc123"},
+		{in: "abc123", want: "abc123"},
+		{in: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := string(trimNewline([]byte(tt.in))); got != tt.want {
+			t.Errorf("trimNewline(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCopyTree(t *testing.T) {
+	src := t.TempDir()
+	dst := filepath.Join(t.TempDir(), "dst")
+
+	if err := os.WriteFile(filepath.Join(src, "script.sh"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(src, "lib"), 0755); err != nil {
+		t.Fatalf("MkdirAll error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "lib", "helper.sh"), []byte("helper\n"), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(src, ".git"), 0755); err != nil {
+		t.Fatalf("MkdirAll error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(src, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	if err := CopyTree(src, dst); err != nil {
+		t.Fatalf("CopyTree error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dst, "script.sh"))
+	if err != nil {
+		t.Fatalf("ReadFile(script.sh) error: %v", err)
+	}
+	if string(data) != "#!/bin/sh\n" {
+		t.Errorf("script.sh content = %q", string(data))
+	}
+
+	data, err = os.ReadFile(filepath.Join(dst, "lib", "helper.sh"))
+	if err != nil {
+		t.Fatalf("ReadFile(lib/helper.sh) error: %v", err)
+	}
+	if string(data) != "helper\n" {
+		t.Errorf("lib/helper.sh content = %q", string(data))
+	}
+
+	if _, err := os.Stat(filepath.Join(dst, ".git")); !os.IsNotExist(err) {
+		t.Errorf(".git directory was copied, should have been skipped (stat err = %v)", err)
+	}
+}
@@ -0,0 +1,169 @@
+package install
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sfkleach/scriptman/pkg/config"
+	"github.com/sfkleach/scriptman/pkg/registry"
+	"github.com/sfkleach/scriptman/pkg/source"
+)
+
+// RunCheck reports, for each installed script named in names (or every
+// installed script if names is empty), whether its source has a commit or
+// release newer than the one currently pinned in the registry. It never
+// modifies the registry or lockfile; see RunUpdate to actually reinstall.
+func RunCheck(names []string) error {
+	reg, err := registry.Load(config.GetDefaultRegistryPath())
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+
+	targets, err := selectScripts(reg, names)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		fmt.Println("No scripts installed.")
+		return nil
+	}
+
+	stale := 0
+	for _, name := range targets {
+		script := reg.Scripts[name]
+		latest, err := latestCommit(script)
+		if err != nil {
+			fmt.Printf("%s: cannot check for updates: %v\n", name, err)
+			continue
+		}
+		if latest == script.Commit {
+			fmt.Printf("%s: up to date (%s)\n", name, shortCommit(script.Commit))
+			continue
+		}
+		stale++
+		fmt.Printf("%s: update available (%s -> %s)\n", name, shortCommit(script.Commit), shortCommit(latest))
+	}
+
+	if stale > 0 {
+		fmt.Printf("\n%d script(s) have updates available; run 'scriptman update' to apply\n", stale)
+	}
+	return nil
+}
+
+// RunUpdate reinstalls each installed script named in names (or every
+// installed script if names is empty) whose source has a commit or release
+// newer than the one currently pinned, using the repo/path/wrapper-mode
+// recorded at install time. A script originally installed with --verify is
+// re-verified against the default trusted keys file (the specific --key
+// path used at install time isn't persisted, so it can't be recovered).
+func RunUpdate(names []string) error {
+	registryPath := config.GetDefaultRegistryPath()
+	reg, err := registry.Load(registryPath)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+	lockfilePath := config.GetDefaultLockfilePath()
+	lock, err := registry.LoadLockfile(lockfilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load lockfile: %w", err)
+	}
+
+	targets, err := selectScripts(reg, names)
+	if err != nil {
+		return err
+	}
+
+	updated := 0
+	for _, name := range targets {
+		script := reg.Scripts[name]
+		latest, err := latestCommit(script)
+		if err != nil {
+			fmt.Printf("%s: cannot check for updates: %v\n", name, err)
+			continue
+		}
+		if latest == script.Commit {
+			fmt.Printf("= '%s' already up to date\n", name)
+			continue
+		}
+
+		fmt.Printf("Updating '%s' (%s -> %s)...\n", name, shortCommit(script.Commit), shortCommit(latest))
+		opts := &Options{
+			Repo:        script.Repo,
+			Path:        script.SourcePath,
+			Name:        name,
+			WrapperMode: script.WrapperMode,
+			Verify:      script.VerifyKeyID != "",
+		}
+		newScript, entry, err := installOne(name, opts)
+		if err != nil {
+			return fmt.Errorf("failed to update '%s': %w", name, err)
+		}
+		reg.Add(name, newScript)
+		lock.Add(name, entry)
+		updated++
+	}
+
+	if err := reg.Save(registryPath); err != nil {
+		return fmt.Errorf("failed to save registry: %w", err)
+	}
+	if err := lock.Save(lockfilePath); err != nil {
+		return fmt.Errorf("failed to save lockfile: %w", err)
+	}
+
+	if updated == 0 {
+		fmt.Println("Everything is up to date.")
+	}
+	return nil
+}
+
+// selectScripts resolves the registry names RunCheck/RunUpdate should act
+// on: every installed script, sorted, if names is empty; otherwise exactly
+// the given names, erroring if any isn't installed.
+func selectScripts(reg *registry.Registry, names []string) ([]string, error) {
+	if len(names) == 0 {
+		all := make([]string, 0, len(reg.Scripts))
+		for name := range reg.Scripts {
+			all = append(all, name)
+		}
+		sort.Strings(all)
+		return all, nil
+	}
+	for _, name := range names {
+		if !reg.Exists(name) {
+			return nil, fmt.Errorf("script '%s' not found in registry", name)
+		}
+	}
+	return names, nil
+}
+
+// latestCommit resolves the newest commit script's source currently has:
+// the latest release's commit if script was pinned to a release tag,
+// otherwise the default branch's head commit.
+func latestCommit(script *registry.Script) (string, error) {
+	src, _, err := source.Detect(script.Repo)
+	if err != nil {
+		return "", err
+	}
+
+	if script.Version != "" {
+		latestTag, err := src.GetLatestRelease()
+		if err != nil {
+			return "", err
+		}
+		if latestTag == script.Version {
+			return script.Commit, nil
+		}
+		return src.GetCommit(latestTag)
+	}
+
+	return src.GetCommit("")
+}
+
+// shortCommit truncates a commit SHA to a readable prefix, matching `git`'s
+// own abbreviated-SHA convention.
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
@@ -0,0 +1,65 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/sfkleach/scriptman/pkg/registry"
+)
+
+func TestSelectScripts(t *testing.T) {
+	reg := &registry.Registry{Scripts: map[string]*registry.Script{
+		"b": {},
+		"a": {},
+	}}
+
+	t.Run("AllSortedWhenNamesEmpty", func(t *testing.T) {
+		got, err := selectScripts(reg, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{"a", "b"}
+		if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("NamedNotInstalled", func(t *testing.T) {
+		if _, err := selectScripts(reg, []string{"missing"}); err == nil {
+			t.Fatalf("expected error for unknown script name")
+		}
+	})
+
+	t.Run("NamedExists", func(t *testing.T) {
+		got, err := selectScripts(reg, []string{"b"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 1 || got[0] != "b" {
+			t.Errorf("got %v, want [b]", got)
+		}
+	})
+}
+
+func TestShortCommit(t *testing.T) {
+	tests := []struct {
+		commit string
+		want   string
+	}{
+		{commit: "abc123", want: "abc123"},
+		{commit: "0123456789abcdef", want: "0123456789ab"},
+	}
+	for _, tt := range tests {
+		if got := shortCommit(tt.commit); got != tt.want {
+			t.Errorf("shortCommit(%q) = %q, want %q", tt.commit, got, tt.want)
+		}
+	}
+}
+
+func TestFrozenWrapperMode(t *testing.T) {
+	if got := frozenWrapperMode(&registry.LockEntry{}); got != WrapperModeExec {
+		t.Errorf("frozenWrapperMode(empty) = %q, want %q", got, WrapperModeExec)
+	}
+	if got := frozenWrapperMode(&registry.LockEntry{WrapperMode: WrapperModeShell}); got != WrapperModeShell {
+		t.Errorf("frozenWrapperMode(shell) = %q, want %q", got, WrapperModeShell)
+	}
+}
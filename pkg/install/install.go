@@ -1,15 +1,21 @@
 package install
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/sfkleach/scriptman/pkg/config"
-	"github.com/sfkleach/scriptman/pkg/github"
+	"github.com/sfkleach/scriptman/pkg/git"
 	"github.com/sfkleach/scriptman/pkg/interpreter"
+	"github.com/sfkleach/scriptman/pkg/linter"
 	"github.com/sfkleach/scriptman/pkg/registry"
+	"github.com/sfkleach/scriptman/pkg/source"
+	"github.com/sfkleach/scriptman/pkg/verify"
 	"github.com/sfkleach/scriptman/pkg/wrapper"
 	"github.com/spf13/cobra"
 )
@@ -21,127 +27,783 @@ type Options struct {
 	Interpreter string
 	Name        string
 	Into        string
+	Tag         string
+	Commit      string
+	Frozen      bool
+	FromFile    string
+	Verify      bool
+	Key         string
+	Mode        string
+	WrapperMode string
+	Python      string
+	Ruby        string
+	Node        string
+	Lint        string
+	AssumeYes   bool
+	Default     int
 }
 
+// Fetch modes controlling how a script (and its sibling files) are retrieved.
+const (
+	ModeRaw      = "raw"
+	ModeWorktree = "worktree"
+)
+
+// Wrapper modes controlling how the installed name dispatches to the script.
+const (
+	// WrapperModeExec hardlinks (or symlinks) the wrapper to the scriptman
+	// binary itself; invoking it re-enters scriptman in runner mode, which
+	// execs the resolved interpreter+script in place. Preserves PID and
+	// signal handling, and requires scriptman to remain installed.
+	WrapperModeExec = "exec"
+	// WrapperModeShell writes a standalone `#!/bin/sh` wrapper that execs the
+	// interpreter directly, with no dependency on scriptman at runtime.
+	WrapperModeShell = "shell"
+)
+
 // NewInstallCommand creates the install command.
 func NewInstallCommand() *cobra.Command {
 	opts := &Options{}
 
 	cmd := &cobra.Command{
-		Use:   "install REPO PATH",
-		Short: "Install a script from a GitHub repository",
-		Long: `Install a script from a GitHub repository.
+		Use:   "install [REPO PATH]",
+		Short: "Install a script from a repository",
+		Long: `Install a script from GitHub, GitLab, Gitea, a raw HTTPS URL, or a local
+file:// path. The backend is detected from REPO.
 
 Examples:
   scriptman install owner/repo scripts/myscript.py
   scriptman install owner/repo scripts/tool.rb --name mytool
   scriptman install owner/repo scripts/app.py --interpreter python3.11
-  scriptman install owner/repo scripts/util.sh --into ~/bin`,
-		Args: cobra.ExactArgs(2),
+  scriptman install owner/repo scripts/app.py --python 3.11
+  scriptman install owner/repo scripts/util.sh --into ~/bin
+  scriptman install owner/repo scripts/tool.sh --lint=strict
+  scriptman install owner/repo scripts/tool.py --tag v1.2.0
+  scriptman install owner/repo scripts/tool.py --commit abc123
+  scriptman install gitlab.com/owner/repo scripts/tool.sh
+  scriptman install file:///srv/mirror scripts/tool.sh
+  scriptman install --frozen
+  scriptman install --from-file scripts.yaml`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.Frozen || opts.FromFile != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.Repo = args[0]
-			opts.Path = args[1]
-			return runInstall(opts)
+			switch {
+			case opts.Frozen:
+				return runFrozenInstall()
+			case opts.FromFile != "":
+				return RunSync(opts.FromFile, false)
+			default:
+				opts.Repo = args[0]
+				opts.Path = args[1]
+				return runInstall(opts)
+			}
 		},
 	}
 
 	cmd.Flags().StringVar(&opts.Interpreter, "interpreter", "", "Explicit interpreter command")
 	cmd.Flags().StringVar(&opts.Name, "name", "", "Name for the wrapper (defaults to script filename without extension)")
 	cmd.Flags().StringVar(&opts.Into, "into", "", "Target directory for wrapper (defaults to ~/.local/bin)")
+	cmd.Flags().StringVar(&opts.Tag, "tag", "", "Pin the install to a specific release tag")
+	cmd.Flags().StringVar(&opts.Commit, "commit", "", "Pin the install to a specific commit SHA")
+	cmd.Flags().BoolVar(&opts.Frozen, "frozen", false, "Reinstall every script in the lockfile at its pinned commit")
+	cmd.Flags().StringVar(&opts.FromFile, "from-file", "", "Install every script declared in a manifest file")
+	cmd.Flags().BoolVar(&opts.Verify, "verify", false, "Require a detached minisign signature before installing")
+	cmd.Flags().StringVar(&opts.Key, "key", "", "Trusted public key file (defaults to ~/.config/scriptman/trusted_keys)")
+	cmd.Flags().StringVar(&opts.Mode, "mode", "", "Fetch mode: 'raw' (single file) or 'worktree' (clone the repo, for scripts with sibling imports). Auto-detected from PATH when omitted.")
+	cmd.Flags().StringVar(&opts.WrapperMode, "wrapper-mode", "", "Wrapper mode: 'exec' (re-enter scriptman for correct PID/signal handling, default) or 'shell' (standalone script, no scriptman dependency at runtime)")
+	cmd.Flags().StringVar(&opts.Python, "python", "", "Pin to a specific Python version (e.g. 3.11), Python Launcher -V: style; overrides any shebang")
+	cmd.Flags().StringVar(&opts.Ruby, "ruby", "", "Pin to a specific Ruby version (e.g. 3.2); overrides any shebang")
+	cmd.Flags().StringVar(&opts.Node, "node", "", "Pin to a specific Node version (e.g. 18); overrides any shebang")
+	cmd.Flags().StringVar(&opts.Lint, "lint", linter.ModeOff, "Lint gate before install: 'off' (default), 'warn' (report findings but continue), or 'strict' (abort install on any finding)")
+	cmd.Flags().BoolVar(&opts.AssumeYes, "assume-yes", false, "Answer any interpreter prompt with the recommended choice instead of asking interactively (for CI/scripted use)")
+	cmd.Flags().IntVar(&opts.Default, "default", 0, "Answer any interpreter prompt with option N instead of asking interactively (for CI/scripted use)")
 
 	return cmd
 }
 
-// runInstall executes the install command.
+// runInstall executes the install command for a single script.
 func runInstall(opts *Options) error {
-	// Determine wrapper name.
-	name := opts.Name
-	if name == "" {
-		// Default to script filename without extension.
-		base := filepath.Base(opts.Path)
-		name = strings.TrimSuffix(base, filepath.Ext(base))
+	if opts.Tag != "" && opts.Commit != "" {
+		return fmt.Errorf("--tag and --commit are mutually exclusive")
 	}
 
-	// Check for reserved name.
+	applyPrompterOptions(opts)
+
+	name := wrapperName(opts)
 	if name == "scriptman" {
 		return fmt.Errorf("'scriptman' is reserved for the management CLI\nChoose a different name with --name")
 	}
 
-	// Determine target directory.
-	binDir := opts.Into
-	if binDir == "" {
-		var err error
-		binDir, err = config.GetDefaultBinDir()
-		if err != nil {
-			return fmt.Errorf("failed to get default bin directory: %w", err)
-		}
-	}
-
-	// Load registry.
 	registryPath := config.GetDefaultRegistryPath()
 	reg, err := registry.Load(registryPath)
 	if err != nil {
 		return fmt.Errorf("failed to load registry: %w", err)
 	}
 
-	// Check if name already exists.
 	if reg.Exists(name) {
 		return fmt.Errorf("script '%s' is already installed\nUse 'scriptman remove %s' first or choose a different name with --name", name, name)
 	}
 
-	// Fetch script from GitHub.
-	fmt.Printf("Fetching %s from %s...\n", opts.Path, opts.Repo)
-	scriptContent, err := github.FetchScript(opts.Repo, opts.Path)
+	lockfilePath := config.GetDefaultLockfilePath()
+	lock, err := registry.LoadLockfile(lockfilePath)
 	if err != nil {
-		return fmt.Errorf("failed to fetch script: %w", err)
+		return fmt.Errorf("failed to load lockfile: %w", err)
 	}
 
-	// Detect interpreter.
-	fmt.Println("Detecting interpreter...")
-	interpPath, err := interpreter.Detect(opts.Path, scriptContent, opts.Interpreter)
+	script, entry, err := installOne(name, opts)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Using interpreter: %s\n", interpPath)
 
-	// Determine script storage location.
-	scriptDir, err := config.GetDefaultScriptDir()
+	reg.Add(name, script)
+	if err := reg.Save(registryPath); err != nil {
+		return fmt.Errorf("failed to save registry: %w", err)
+	}
+
+	lock.Add(name, entry)
+	if err := lock.Save(lockfilePath); err != nil {
+		return fmt.Errorf("failed to save lockfile: %w", err)
+	}
+
+	fmt.Printf("\n✓ Installed '%s' successfully\n", name)
+	fmt.Printf("  Wrapper: %s\n", script.WrapperPath)
+	fmt.Printf("  Script:  %s\n", script.LocalScript)
+	if script.Commit != "" {
+		fmt.Printf("  Commit:  %s\n", script.Commit)
+	}
+
+	return nil
+}
+
+// applyPrompterOptions points interpreter.ActivePrompter at a
+// NonInteractivePrompter when --assume-yes or --default is given, so a
+// required interpreter prompt (ambiguous shebang, low-confidence content
+// guess, ...) resolves automatically instead of hanging CI on a stdin read.
+// Leaves the default interactive TTYPrompter in place otherwise.
+func applyPrompterOptions(opts *Options) {
+	if !opts.AssumeYes && opts.Default == 0 {
+		return
+	}
+	interpreter.ActivePrompter = interpreter.NonInteractivePrompter{
+		AssumeYes: opts.AssumeYes,
+		Default:   opts.Default,
+	}
+}
+
+// wrapperName determines the wrapper name for an install, defaulting to the
+// script filename without extension.
+func wrapperName(opts *Options) string {
+	if opts.Name != "" {
+		return opts.Name
+	}
+	base := filepath.Base(opts.Path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// installOne fetches, detects, saves and wraps a single script, returning
+// the registry and lockfile entries to record. It is the core installer
+// shared by the single-script path and the manifest-driven `sync` path.
+func installOne(name string, opts *Options) (*registry.Script, *registry.LockEntry, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	binDir := opts.Into
+	if binDir == "" {
+		binDir = config.GetDefaultBinDir()
+	}
+
+	// Pin to a specific commit if requested; otherwise resolve against tag/main.
+	ref := opts.Commit
+	if ref == "" {
+		ref = opts.Tag
+	}
+
+	src, repo, err := source.Detect(opts.Repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if fetchMode(opts) == ModeWorktree {
+		return installFromWorktree(name, binDir, ref, opts, src, repo)
+	}
+
+	fmt.Printf("Fetching %s from %s (%s)...\n", opts.Path, repo, src.Type())
+	fetched, err := src.FetchScript(opts.Path, ref)
 	if err != nil {
-		return fmt.Errorf("failed to get default script directory: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch script: %w", err)
 	}
-	localScriptPath := filepath.Join(scriptDir, filepath.Base(opts.Path))
 
-	// Save script.
+	// A pinned commit should resolve to itself; otherwise capture whatever
+	// FetchScript resolved (latest on main, or the tag's commit).
+	resolvedCommit := fetched.Commit
+	if opts.Commit != "" {
+		resolvedCommit = opts.Commit
+	}
+
+	interpPath, interpArgs, _, scriptFilename, includeShebangLine, err := interpreter.Detect(opts.Path, fetched.Content, explicitInterpreter(opts), false)
+	if err != nil {
+		return nil, nil, err
+	}
+	fmt.Printf("Using interpreter: %s\n", interpPath)
+
+	var verifyResult *verify.Result
+	if opts.Verify {
+		verifyResult, err = verifyScript(src, opts.Path, ref, fetched.Content, opts.Key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("signature verification failed: %w", err)
+		}
+		fmt.Printf("Verified signature from key %s\n", verifyResult.KeyID)
+	}
+
+	scriptDir := config.GetDefaultScriptDir()
+	localName := filepath.Base(opts.Path)
+	if scriptFilename != "" {
+		localName = scriptFilename
+	}
+	localScriptPath := filepath.Join(scriptDir, localName)
+
+	localContent := fetched.Content
+	if !includeShebangLine {
+		localContent = interpreter.StripShebangLine(localContent)
+	}
+
+	// Critical invariant: verification above must complete (and succeed)
+	// before the script is ever written to disk.
 	fmt.Printf("Saving script to %s...\n", localScriptPath)
-	if err := github.SaveScript(scriptContent, localScriptPath); err != nil {
-		return fmt.Errorf("failed to save script: %w", err)
+	if err := source.SaveScript(localContent, localScriptPath, 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to save script: %w", err)
+	}
+
+	if err := runLintGate(opts, localScriptPath, interpPath, localContent); err != nil {
+		_ = os.Remove(localScriptPath)
+		return nil, nil, err
 	}
 
-	// Create wrapper.
-	fmt.Println("Creating shell script wrapper...")
 	wrapperPath := filepath.Join(binDir, name)
-	if err := wrapper.CreateWrapper(interpPath, localScriptPath, wrapperPath); err != nil {
-		return fmt.Errorf("failed to create wrapper: %w", err)
+	mode := wrapperMode(opts)
+	if err := makeWrapper(mode, interpPath, interpArgs, localScriptPath, wrapperPath, ""); err != nil {
+		return nil, nil, fmt.Errorf("failed to create wrapper: %w", err)
 	}
 
-	// Add to registry.
-	reg.Add(name, &registry.Script{
-		Repo:        opts.Repo,
+	script := &registry.Script{
+		Repo:        repo,
+		SourceType:  src.Type(),
 		SourcePath:  opts.Path,
 		LocalScript: localScriptPath,
 		Interpreter: interpPath,
+		Args:        interpArgs,
 		WrapperPath: wrapperPath,
+		WrapperMode: mode,
 		InstalledAt: time.Now(),
-	})
+		Version:     fetched.Tag,
+		Commit:      resolvedCommit,
+	}
+	if verifyResult != nil {
+		script.VerifyKeyID = verifyResult.KeyID
+		script.Signature = verifyResult.Signature
+	}
+	entry := &registry.LockEntry{
+		Repo:        repo,
+		SourceType:  src.Type(),
+		SourcePath:  opts.Path,
+		Commit:      resolvedCommit,
+		ContentHash: hashContent(fetched.Content),
+		Interpreter: interpPath,
+		Args:        interpArgs,
+		WrapperMode: mode,
+	}
+
+	return script, entry, nil
+}
+
+// wrapperMode resolves the effective wrapper mode: explicit --wrapper-mode
+// wins, defaulting to WrapperModeExec otherwise.
+func wrapperMode(opts *Options) string {
+	if opts.WrapperMode != "" {
+		return opts.WrapperMode
+	}
+	return WrapperModeExec
+}
+
+// makeWrapper creates the wrapper at wrapperPath according to mode.
+// interpArgs are the interpreter's own arguments (see InterpreterChoice.Args)
+// and are baked into the shell wrapper verbatim; runner mode (exec wrapper)
+// instead reads them from the registry entry at exec time (see
+// cmd/scriptman/main.go's runScript). rootDir, if non-empty, is the worktree
+// root the shell wrapper should cd into before exec; it has no effect in
+// exec mode since runner mode resolves the root from the registry entry
+// itself.
+func makeWrapper(mode, interpPath string, interpArgs []string, scriptPath, wrapperPath, rootDir string) error {
+	if mode == WrapperModeShell {
+		fmt.Println("Creating shell script wrapper...")
+		return wrapper.CreateWrapperInDir(interpPath, interpArgs, scriptPath, wrapperPath, rootDir)
+	}
+
+	fmt.Println("Creating exec wrapper...")
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate scriptman executable: %w", err)
+	}
+	return wrapper.CreateExecWrapper(self, wrapperPath)
+}
+
+// versionOverride turns --python/--ruby/--node into an explicit
+// "family<constraint>" interpreter string, consulted by explicitInterpreter
+// only when no literal --interpreter value was given. A two-segment version like
+// "3.11" becomes the range constraint ">=3.11,<3.12" (see
+// interpreter.VersionRangeConstraint) so it matches any patch release,
+// rather than an exact match that would reject every real "3.11.x" binary.
+// Empty if none of the three flags were given.
+func versionOverride(opts *Options) string {
+	switch {
+	case opts.Python != "":
+		return "python" + interpreter.VersionRangeConstraint(opts.Python)
+	case opts.Ruby != "":
+		return "ruby" + interpreter.VersionRangeConstraint(opts.Ruby)
+	case opts.Node != "":
+		return "node" + interpreter.VersionRangeConstraint(opts.Node)
+	default:
+		return ""
+	}
+}
+
+// explicitInterpreter resolves the effective --interpreter value passed to
+// interpreter.Detect: a literal --interpreter wins, otherwise a --python/
+// --ruby/--node version pin, otherwise empty (fall through to shebang/
+// policy/extension detection).
+func explicitInterpreter(opts *Options) string {
+	if opts.Interpreter != "" {
+		return opts.Interpreter
+	}
+	return versionOverride(opts)
+}
+
+// runLintGate runs the registered linter (see pkg/linter) for interpPath's
+// family over the saved script at scriptPath, per opts.Lint:
+//   - "off" (default): skipped entirely.
+//   - "warn": diagnostics are printed to stderr but never fail the install.
+//   - "strict": aborts the install - returning an error the caller uses to
+//     remove the just-saved script - if the linter itself fails to run
+//     (e.g. the tool isn't installed, so the gate can't vouch for anything)
+//     or reports any "error"-severity diagnostic.
+//
+// A family with no registered linter is silently skipped in every mode; this
+// is a best-effort safety net, not a guarantee every language is covered.
+func runLintGate(opts *Options, scriptPath, interpPath string, content []byte) error {
+	mode := opts.Lint
+	if mode == "" {
+		mode = linter.ModeOff
+	}
+	if mode == linter.ModeOff {
+		return nil
+	}
+
+	family := interpreter.Family(interpPath)
+	l, ok := linter.Lookup(family)
+	if !ok {
+		return nil
+	}
+
+	fmt.Printf("Linting %s (%s)...\n", scriptPath, family)
+	diags, err := l.Check(scriptPath, content)
+	if err != nil {
+		if mode == linter.ModeStrict {
+			return fmt.Errorf("lint gate failed to run for %s: %w", family, err)
+		}
+		fmt.Fprintf(os.Stderr, "warning: lint gate could not run for %s: %v\n", family, err)
+		return nil
+	}
+
+	hasError := false
+	for _, d := range diags {
+		if d.Severity == "error" {
+			hasError = true
+		}
+		if d.Line > 0 {
+			fmt.Fprintf(os.Stderr, "lint (%s): %s:%d: %s\n", d.Severity, scriptPath, d.Line, d.Message)
+		} else {
+			fmt.Fprintf(os.Stderr, "lint (%s): %s: %s\n", d.Severity, scriptPath, d.Message)
+		}
+	}
+
+	if mode == linter.ModeStrict && hasError {
+		return fmt.Errorf("lint gate found errors in %s; rerun with --lint=warn to install anyway", scriptPath)
+	}
+
+	return nil
+}
+
+// fetchMode resolves the effective fetch mode: explicit --mode wins,
+// otherwise a trailing slash on PATH is taken to mean "this is a directory,
+// fetch the whole thing".
+func fetchMode(opts *Options) string {
+	if opts.Mode != "" {
+		return opts.Mode
+	}
+	if strings.HasSuffix(opts.Path, "/") {
+		return ModeWorktree
+	}
+	return ModeRaw
+}
+
+// resolveWorktreeEntryPath resolves requestedPath (relative to wtDir) to a
+// concrete entry script. If requestedPath already names a file, it is
+// returned unchanged. If it names a directory (the trailing-slash case
+// fetchMode detects), a single entry script is picked from that directory's
+// top-level, non-hidden files: one named after the wrapper name wins
+// outright; otherwise there must be exactly one candidate, or the caller
+// needs to name the entry script explicitly.
+func resolveWorktreeEntryPath(wtDir, requestedPath, name string) (string, error) {
+	fullPath := filepath.Join(wtDir, requestedPath)
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("%s not found in worktree: %w", requestedPath, err)
+	}
+	if !info.IsDir() {
+		return requestedPath, nil
+	}
+
+	entries, err := os.ReadDir(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read directory %s in worktree: %w", requestedPath, err)
+	}
+
+	var candidates []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		candidates = append(candidates, e.Name())
+	}
+
+	for _, c := range candidates {
+		if strings.TrimSuffix(c, filepath.Ext(c)) == name {
+			return filepath.Join(requestedPath, c), nil
+		}
+	}
+
+	if len(candidates) == 1 {
+		return filepath.Join(requestedPath, candidates[0]), nil
+	}
+
+	return "", fmt.Errorf("cannot determine entry script in directory %s: found %d candidate files (%s); name the entry script explicitly via --path %s/<file>, or use --name to match it", requestedPath, len(candidates), strings.Join(candidates, ", "), requestedPath)
+}
+
+// installFromWorktree shallow-clones the owning repository into a temporary
+// worktree and copies it into versioned script storage, so that scripts
+// which import sibling modules or data files keep working. The wrapper cds
+// into the copied root before exec so relative imports resolve.
+func installFromWorktree(name, binDir, ref string, opts *Options, src source.Source, repo string) (*registry.Script, *registry.LockEntry, error) {
+	cloneURL, ok := src.CloneURL()
+	if !ok {
+		return nil, nil, fmt.Errorf("worktree mode is not supported for %s sources", src.Type())
+	}
+
+	requestedPath := strings.TrimSuffix(opts.Path, "/")
+
+	fmt.Printf("Cloning %s into a worktree...\n", cloneURL)
+	wt, err := git.Clone(cloneURL, ref)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to clone worktree: %w", err)
+	}
+	defer wt.Cleanup()
+
+	commit, err := git.HeadCommit(wt.Dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve worktree commit: %w", err)
+	}
+
+	entryPath, err := resolveWorktreeEntryPath(wt.Dir, requestedPath, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := os.ReadFile(filepath.Join(wt.Dir, entryPath))
+	if err != nil {
+		return nil, nil, fmt.Errorf("entry script %s not found in worktree: %w", entryPath, err)
+	}
+
+	// Platform hints (see interpreter.Detect) are ignored here: a worktree
+	// copies the whole tree verbatim via git.CopyTree, including sibling
+	// files the entry script may import, so scriptman cannot rename or
+	// rewrite the entry script in isolation without breaking those imports.
+	interpPath, interpArgs, _, _, _, err := interpreter.Detect(entryPath, content, explicitInterpreter(opts), false)
+	if err != nil {
+		return nil, nil, err
+	}
+	fmt.Printf("Using interpreter: %s\n", interpPath)
+
+	// Critical invariant, same as the raw-fetch path in installOne: verify
+	// must complete (and succeed) before the worktree is ever copied into
+	// versioned storage. Fetched fresh from src rather than read out of the
+	// clone, since a compromised clone could carry a signature for
+	// different content than what it actually contains.
+	var verifyResult *verify.Result
+	if opts.Verify {
+		verifyResult, err = verifyScript(src, entryPath, ref, content, opts.Key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("signature verification failed: %w", err)
+		}
+		fmt.Printf("Verified signature from key %s\n", verifyResult.KeyID)
+	}
+
+	rootDir := filepath.Join(config.GetDefaultScriptDir(), name, commit)
+	fmt.Printf("Copying worktree to %s...\n", rootDir)
+	if err := git.CopyTree(wt.Dir, rootDir); err != nil {
+		return nil, nil, fmt.Errorf("failed to copy worktree: %w", err)
+	}
+	localScriptPath := filepath.Join(rootDir, entryPath)
+
+	if err := runLintGate(opts, localScriptPath, interpPath, content); err != nil {
+		return nil, nil, err
+	}
+
+	wrapperPath := filepath.Join(binDir, name)
+	mode := wrapperMode(opts)
+	if err := makeWrapper(mode, interpPath, interpArgs, localScriptPath, wrapperPath, rootDir); err != nil {
+		return nil, nil, fmt.Errorf("failed to create wrapper: %w", err)
+	}
+
+	script := &registry.Script{
+		Repo:        repo,
+		SourceType:  src.Type(),
+		SourcePath:  opts.Path,
+		LocalScript: localScriptPath,
+		RootDir:     rootDir,
+		Interpreter: interpPath,
+		Args:        interpArgs,
+		WrapperPath: wrapperPath,
+		WrapperMode: mode,
+		InstalledAt: time.Now(),
+		Commit:      commit,
+	}
+	if verifyResult != nil {
+		script.VerifyKeyID = verifyResult.KeyID
+		script.Signature = verifyResult.Signature
+	}
+	entry := &registry.LockEntry{
+		Repo:        repo,
+		SourceType:  src.Type(),
+		SourcePath:  opts.Path,
+		Commit:      commit,
+		ContentHash: hashContent(content),
+		Interpreter: interpPath,
+		Args:        interpArgs,
+		WrapperMode: mode,
+		IsWorktree:  true,
+	}
+
+	return script, entry, nil
+}
+
+// runFrozenInstall reinstalls every script recorded in the lockfile at its
+// exact pinned commit, without consulting the latest ref on main or the
+// latest release tag. It is used to reproduce a toolchain across machines.
+func runFrozenInstall() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	lockfilePath := config.GetDefaultLockfilePath()
+	lock, err := registry.LoadLockfile(lockfilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load lockfile: %w", err)
+	}
+	if len(lock.Scripts) == 0 {
+		return fmt.Errorf("no lockfile found at %s; run 'scriptman install' first", lockfilePath)
+	}
+
+	registryPath := config.GetDefaultRegistryPath()
+	reg, err := registry.Load(registryPath)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+
+	binDir := config.GetDefaultBinDir()
+
+	for name, entry := range lock.Scripts {
+		src, repo, err := source.Detect(entry.Repo)
+		if err != nil {
+			return fmt.Errorf("failed to resolve source for '%s': %w", name, err)
+		}
+
+		var script *registry.Script
+		if entry.IsWorktree {
+			script, err = reinstallWorktree(name, binDir, src, repo, entry)
+		} else {
+			script, err = reinstallRaw(name, binDir, src, repo, entry)
+		}
+		if err != nil {
+			return err
+		}
+
+		reg.Add(name, script)
+		fmt.Printf("✓ Installed '%s' at %s\n", name, entry.Commit)
+	}
 
-	// Save registry.
 	if err := reg.Save(registryPath); err != nil {
 		return fmt.Errorf("failed to save registry: %w", err)
 	}
 
-	fmt.Printf("\n✓ Installed '%s' successfully\n", name)
-	fmt.Printf("  Wrapper: %s\n", wrapperPath)
-	fmt.Printf("  Script:  %s\n", localScriptPath)
-
 	return nil
 }
+
+// reinstallRaw reproduces a single-file, non-worktree install from its
+// pinned lockfile entry, routing the wrapper through makeWrapper (keyed off
+// the entry's recorded WrapperMode) rather than unconditionally writing a
+// shell wrapper, so --frozen reproduces exec-mode installs faithfully.
+func reinstallRaw(name, binDir string, src source.Source, repo string, entry *registry.LockEntry) (*registry.Script, error) {
+	scriptDir := config.GetDefaultScriptDir()
+
+	fmt.Printf("Fetching %s from %s at %s...\n", entry.SourcePath, repo, entry.Commit)
+	fetched, err := src.FetchScript(entry.SourcePath, entry.Commit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pinned script '%s': %w", name, err)
+	}
+
+	if got := hashContent(fetched.Content); got != entry.ContentHash {
+		return nil, fmt.Errorf("content hash mismatch for '%s': lockfile has %s, fetched %s", name, entry.ContentHash, got)
+	}
+
+	localScriptPath := filepath.Join(scriptDir, filepath.Base(entry.SourcePath))
+	if err := source.SaveScript(fetched.Content, localScriptPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to save script '%s': %w", name, err)
+	}
+
+	wrapperPath := filepath.Join(binDir, name)
+	mode := frozenWrapperMode(entry)
+	if err := makeWrapper(mode, entry.Interpreter, entry.Args, localScriptPath, wrapperPath, ""); err != nil {
+		return nil, fmt.Errorf("failed to create wrapper for '%s': %w", name, err)
+	}
+
+	return &registry.Script{
+		Repo:        repo,
+		SourceType:  src.Type(),
+		SourcePath:  entry.SourcePath,
+		LocalScript: localScriptPath,
+		Interpreter: entry.Interpreter,
+		Args:        entry.Args,
+		WrapperPath: wrapperPath,
+		WrapperMode: mode,
+		InstalledAt: time.Now(),
+		Commit:      entry.Commit,
+	}, nil
+}
+
+// reinstallWorktree reproduces a multi-file worktree install from its
+// pinned lockfile entry: it re-clones the owning repo at the exact pinned
+// commit, recopies the tree into the same deterministic RootDir a fresh
+// worktree install would use (scriptDir/name/commit), and rebuilds the
+// wrapper with that RootDir so the wrapper's `cd` before exec still
+// resolves.
+func reinstallWorktree(name, binDir string, src source.Source, repo string, entry *registry.LockEntry) (*registry.Script, error) {
+	cloneURL, ok := src.CloneURL()
+	if !ok {
+		return nil, fmt.Errorf("source for '%s' no longer supports worktree cloning", name)
+	}
+
+	fmt.Printf("Cloning %s at %s into a worktree...\n", cloneURL, entry.Commit)
+	wt, err := git.Clone(cloneURL, entry.Commit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone worktree for '%s': %w", name, err)
+	}
+	defer wt.Cleanup()
+
+	requestedPath := strings.TrimSuffix(entry.SourcePath, "/")
+	entryPath, err := resolveWorktreeEntryPath(wt.Dir, requestedPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(filepath.Join(wt.Dir, entryPath))
+	if err != nil {
+		return nil, fmt.Errorf("entry script %s not found in worktree for '%s': %w", entryPath, name, err)
+	}
+	if got := hashContent(content); got != entry.ContentHash {
+		return nil, fmt.Errorf("content hash mismatch for '%s': lockfile has %s, fetched %s", name, entry.ContentHash, got)
+	}
+
+	rootDir := filepath.Join(config.GetDefaultScriptDir(), name, entry.Commit)
+	if err := git.CopyTree(wt.Dir, rootDir); err != nil {
+		return nil, fmt.Errorf("failed to copy worktree for '%s': %w", name, err)
+	}
+	localScriptPath := filepath.Join(rootDir, entryPath)
+
+	wrapperPath := filepath.Join(binDir, name)
+	mode := frozenWrapperMode(entry)
+	if err := makeWrapper(mode, entry.Interpreter, entry.Args, localScriptPath, wrapperPath, rootDir); err != nil {
+		return nil, fmt.Errorf("failed to create wrapper for '%s': %w", name, err)
+	}
+
+	return &registry.Script{
+		Repo:        repo,
+		SourceType:  src.Type(),
+		SourcePath:  entry.SourcePath,
+		LocalScript: localScriptPath,
+		RootDir:     rootDir,
+		Interpreter: entry.Interpreter,
+		Args:        entry.Args,
+		WrapperPath: wrapperPath,
+		WrapperMode: mode,
+		InstalledAt: time.Now(),
+		Commit:      entry.Commit,
+	}, nil
+}
+
+// frozenWrapperMode resolves the wrapper mode a lockfile entry should be
+// reinstalled with, defaulting to WrapperModeExec for entries pinned before
+// WrapperMode was recorded.
+func frozenWrapperMode(entry *registry.LockEntry) string {
+	if entry.WrapperMode != "" {
+		return entry.WrapperMode
+	}
+	return WrapperModeExec
+}
+
+// hashContent returns the hex-encoded SHA-256 digest of script content, used
+// to detect drift between the lockfile and what is actually installed.
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyScript fetches the detached signature alongside path (trying
+// "<path>.minisig" then "<path>.sig") and checks it against trusted keys
+// loaded from keyPath, or ~/.config/scriptman/trusted_keys if keyPath is
+// empty.
+func verifyScript(src source.Source, path, ref string, content []byte, keyPath string) (*verify.Result, error) {
+	if keyPath == "" {
+		keyPath = config.GetDefaultTrustedKeysPath()
+	}
+	keys, err := verify.LoadTrustedKeys(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var sigData []byte
+	for _, suffix := range []string{".minisig", ".sig"} {
+		fetched, err := src.FetchScript(path+suffix, ref)
+		if err == nil {
+			sigData = fetched.Content
+			break
+		}
+	}
+	if sigData == nil {
+		return nil, fmt.Errorf("no signature found at %s.minisig or %s.sig", path, path)
+	}
+
+	return verify.Verify(content, sigData, keys)
+}
@@ -0,0 +1,114 @@
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestResolveWorktreeEntryPath covers the directory-vs-file resolution that
+// backs `--mode worktree`'s trailing-slash trigger: a bare file path is
+// returned unchanged, a directory with a file named after the wrapper wins
+// outright, a directory with exactly one candidate file falls back to it,
+// and an ambiguous directory is a clear error rather than a failed read.
+func TestResolveWorktreeEntryPath(t *testing.T) {
+	wtDir := t.TempDir()
+
+	writeFile := func(rel string) {
+		full := filepath.Join(wtDir, rel)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("MkdirAll(%s): %v", filepath.Dir(full), err)
+		}
+		if err := os.WriteFile(full, []byte("content"), 0644); err != nil {
+			t.Fatalf("WriteFile(%s): %v", full, err)
+		}
+	}
+
+	writeFile("bin/tool.py")
+	writeFile("scripts/single/only.py")
+	writeFile("scripts/ambiguous/a.py")
+	writeFile("scripts/ambiguous/b.py")
+	writeFile("scripts/named/mytool.py")
+	writeFile("scripts/named/helper.py")
+
+	tests := []struct {
+		name          string
+		requestedPath string
+		wrapperName   string
+		want          string
+		wantErr       bool
+	}{
+		{
+			name:          "PlainFile",
+			requestedPath: "bin/tool.py",
+			wrapperName:   "tool",
+			want:          "bin/tool.py",
+		},
+		{
+			name:          "DirectoryWithSingleCandidate",
+			requestedPath: "scripts/single",
+			wrapperName:   "mytool",
+			want:          "scripts/single/only.py",
+		},
+		{
+			name:          "DirectoryWithNamedMatch",
+			requestedPath: "scripts/named",
+			wrapperName:   "mytool",
+			want:          "scripts/named/mytool.py",
+		},
+		{
+			name:          "DirectoryAmbiguous",
+			requestedPath: "scripts/ambiguous",
+			wrapperName:   "mytool",
+			wantErr:       true,
+		},
+		{
+			name:          "MissingPath",
+			requestedPath: "does/not/exist",
+			wrapperName:   "mytool",
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveWorktreeEntryPath(wtDir, tt.requestedPath, tt.wrapperName)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got entry %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestVersionOverride covers the chunk2-3 regression: --python/--ruby/--node
+// must build a range constraint (matching any patch release), not a literal
+// "==" constraint that would reject every real "--version" output, which
+// always reports a patch number.
+func TestVersionOverride(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *Options
+		want string
+	}{
+		{name: "Python", opts: &Options{Python: "3.11"}, want: "python>=3.11,<3.12"},
+		{name: "Ruby", opts: &Options{Ruby: "3.2"}, want: "ruby>=3.2,<3.3"},
+		{name: "Node", opts: &Options{Node: "18"}, want: "node>=18,<19"},
+		{name: "NoneGiven", opts: &Options{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versionOverride(tt.opts); got != tt.want {
+				t.Errorf("versionOverride(%+v) = %q, want %q", tt.opts, got, tt.want)
+			}
+		})
+	}
+}
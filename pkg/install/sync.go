@@ -0,0 +1,136 @@
+package install
+
+import (
+	"fmt"
+
+	"github.com/sfkleach/scriptman/pkg/config"
+	"github.com/sfkleach/scriptman/pkg/manifest"
+	"github.com/sfkleach/scriptman/pkg/registry"
+	"github.com/sfkleach/scriptman/pkg/source"
+	"github.com/sfkleach/scriptman/pkg/wrapper"
+	"github.com/spf13/cobra"
+)
+
+// NewSyncCommand creates the top-level `scriptman sync` command, a thin
+// wrapper around RunSync for users who prefer it to `install --from-file`.
+func NewSyncCommand() *cobra.Command {
+	var manifestPath string
+	var prune bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Converge installed scripts to match a manifest",
+		Long: `Install, update and (optionally) remove scripts so that the local
+registry matches a declarative manifest file.
+
+Examples:
+  scriptman sync --file scripts.yaml
+  scriptman sync --file scripts.yaml --prune`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if manifestPath == "" {
+				return fmt.Errorf("--file is required")
+			}
+			return RunSync(manifestPath, prune)
+		},
+	}
+
+	cmd.Flags().StringVar(&manifestPath, "file", "", "Path to the manifest file")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Remove installed scripts that are not listed in the manifest")
+
+	return cmd
+}
+
+// RunSync loads a manifest and makes the local registry converge to it:
+// installing missing scripts, updating ones whose repo, path, or pinned
+// tag has drifted (see hasDrifted), and optionally removing scripts that are no
+// longer listed.
+func RunSync(manifestPath string, prune bool) error {
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	registryPath := config.GetDefaultRegistryPath()
+	reg, err := registry.Load(registryPath)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+
+	lockfilePath := config.GetDefaultLockfilePath()
+	lock, err := registry.LoadLockfile(lockfilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load lockfile: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(m.Scripts))
+
+	for _, e := range m.Scripts {
+		name := e.EntryName()
+		wanted[name] = true
+
+		opts := &Options{
+			Repo:        e.Repo,
+			Path:        e.Path,
+			Name:        name,
+			Interpreter: e.Interpreter,
+			Into:        e.Into,
+			Tag:         e.Tag,
+		}
+
+		existing, exists := reg.Scripts[name]
+		if exists && !hasDrifted(existing, opts) {
+			fmt.Printf("= '%s' already up to date\n", name)
+			continue
+		}
+
+		verb := "Installing"
+		if exists {
+			verb = "Updating"
+		}
+		fmt.Printf("%s '%s'...\n", verb, name)
+
+		script, entry, err := installOne(name, opts)
+		if err != nil {
+			return fmt.Errorf("failed to sync '%s': %w", name, err)
+		}
+		reg.Add(name, script)
+		lock.Add(name, entry)
+	}
+
+	if prune {
+		for name, script := range reg.Scripts {
+			if wanted[name] {
+				continue
+			}
+			fmt.Printf("- Removing '%s' (not listed in manifest)\n", name)
+			if err := wrapper.Remove(script.WrapperPath); err != nil {
+				return fmt.Errorf("failed to remove wrapper for '%s': %w", name, err)
+			}
+			reg.Remove(name)
+			lock.Remove(name)
+		}
+	}
+
+	if err := reg.Save(registryPath); err != nil {
+		return fmt.Errorf("failed to save registry: %w", err)
+	}
+	if err := lock.Save(lockfilePath); err != nil {
+		return fmt.Errorf("failed to save lockfile: %w", err)
+	}
+
+	return nil
+}
+
+// hasDrifted reports whether an installed script no longer matches what the
+// manifest now declares for it: a different backend, repo, path, or pinned
+// tag. A changed interpreter or into directory is not detected.
+func hasDrifted(script *registry.Script, opts *Options) bool {
+	src, repo, err := source.Detect(opts.Repo)
+	if err != nil || script.SourceType != src.Type() || script.Repo != repo || script.SourcePath != opts.Path {
+		return true
+	}
+	if opts.Tag != "" && script.Version != opts.Tag {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+package interpreter
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// classifierHighConfidence is the threshold above which a single classifier
+// candidate is treated as automatic rather than prompted.
+const classifierHighConfidence = 0.75
+
+// classifierCloseMargin bounds how far below the top-scoring candidate
+// another candidate can be and still be surfaced as a competing choice,
+// rather than discarded as a weak guess.
+const classifierCloseMargin = 0.15
+
+// Candidate is a single interpreter-family guess produced by a Classifier,
+// ranked by Confidence (0-1; higher is more certain).
+type Candidate struct {
+	Family      string
+	Interpreter string
+	Confidence  float64
+	Reason      string
+}
+
+// Classifier inspects a bounded prefix of a script's content and returns
+// ranked interpreter-family candidates. See RegisterClassifier to register
+// additional detectors beyond the built-in ones below.
+type Classifier interface {
+	Classify(content []byte) []Candidate
+}
+
+// ClassifierFunc adapts a plain function to the Classifier interface.
+type ClassifierFunc func(content []byte) []Candidate
+
+func (f ClassifierFunc) Classify(content []byte) []Candidate {
+	return f(content)
+}
+
+var rubyDefEndRe = regexp.MustCompile(`(?s)\bdef\s+\w+.*?\bend\b`)
+
+var pythonClassifier = ClassifierFunc(func(content []byte) []Candidate {
+	hits := 0
+	for _, pat := range [][]byte{[]byte("import "), []byte("def "), []byte("print(")} {
+		if bytes.Contains(content, pat) {
+			hits++
+		}
+	}
+	if hits < 2 {
+		// A single pattern (e.g. a bare "print(") is too common across
+		// languages to be worth a guess on its own.
+		return nil
+	}
+	return []Candidate{{
+		Family:      "python",
+		Interpreter: "python3",
+		Confidence:  0.3 * float64(hits),
+		Reason:      "import/def/print( patterns",
+	}}
+})
+
+var rubyClassifier = ClassifierFunc(func(content []byte) []Candidate {
+	hits := 0
+	if bytes.Contains(content, []byte("require '")) {
+		hits++
+	}
+	if bytes.Contains(content, []byte("puts ")) {
+		hits++
+	}
+	if rubyDefEndRe.Match(content) {
+		hits++
+	}
+	if hits < 2 {
+		return nil
+	}
+	return []Candidate{{
+		Family:      "ruby",
+		Interpreter: "ruby",
+		Confidence:  0.3 * float64(hits),
+		Reason:      "require '.../puts .../def...end patterns",
+	}}
+})
+
+var phpClassifier = ClassifierFunc(func(content []byte) []Candidate {
+	if !bytes.Contains(content, []byte("<?php")) {
+		return nil
+	}
+	return []Candidate{{
+		Family:      "php",
+		Interpreter: "php",
+		Confidence:  0.95,
+		Reason:      "<?php tag",
+	}}
+})
+
+var nodeFunctionRe = regexp.MustCompile(`function\s*\w*\s*\([^)]*\)\s*\{`)
+
+var nodeClassifier = ClassifierFunc(func(content []byte) []Candidate {
+	if !nodeFunctionRe.Match(content) {
+		return nil
+	}
+	hits := 1
+	if bytes.Contains(content, []byte("var ")) || bytes.Contains(content, []byte("const ")) {
+		hits++
+	}
+	return []Candidate{{
+		Family:      "javascript",
+		Interpreter: "node",
+		Confidence:  0.35 * float64(hits),
+		Reason:      "function(...) { plus var/const",
+	}}
+})
+
+var luaClassifier = ClassifierFunc(func(content []byte) []Candidate {
+	if bytes.Contains(content, []byte("local ")) && bytes.Contains(content, []byte("function")) {
+		return []Candidate{{
+			Family:      "lua",
+			Interpreter: "lua",
+			Confidence:  0.6,
+			Reason:      "local ... function pattern",
+		}}
+	}
+	return nil
+})
+
+// shellReExecRe matches the common shell self-re-exec trick, e.g.
+// `exec perl -x $0 "$@"`, where a shell script hands itself off to a real
+// interpreter named after "exec".
+var shellReExecRe = regexp.MustCompile(`exec\s+(\w+)[^\n]*\$0[^\n]*\$@`)
+
+var shellReExecClassifier = ClassifierFunc(func(content []byte) []Candidate {
+	m := shellReExecRe.FindSubmatch(content)
+	if m == nil {
+		return nil
+	}
+	interp := string(m[1])
+	return []Candidate{{
+		Family:      interp,
+		Interpreter: interp,
+		Confidence:  0.85,
+		Reason:      fmt.Sprintf("shell re-exec into %s via $0/$@", interp),
+	}}
+})
+
+// classifiers is the registered set of content classifiers, consulted in
+// order - mirroring go-enry's strategy chain - with every classifier's
+// candidates merged rather than stopping at the first match.
+var classifiers = []Classifier{
+	shellReExecClassifier,
+	phpClassifier,
+	pythonClassifier,
+	rubyClassifier,
+	nodeClassifier,
+	luaClassifier,
+}
+
+// RegisterClassifier adds c to the set of classifiers consulted by content
+// analysis, the last-resort fallback tried after extension mapping fails
+// and before DetermineInterpreterChoices gives up.
+func RegisterClassifier(c Classifier) {
+	classifiers = append(classifiers, c)
+}
+
+// classifyChoices runs every registered classifier over a bounded prefix of
+// scriptContent and turns the resulting candidates into InterpreterChoices:
+// a single high-confidence candidate becomes an automatic choice, several
+// similarly-scored candidates are all surfaced for the user to pick from,
+// and a single low-confidence candidate still requires confirmation.
+func classifyChoices(scriptContent []byte) []InterpreterChoice {
+	prefix := scriptContent
+	if len(prefix) > sniffPrefixBytes {
+		prefix = prefix[:sniffPrefixBytes]
+	}
+
+	best := make(map[string]Candidate)
+	for _, c := range classifiers {
+		for _, cand := range c.Classify(prefix) {
+			if existing, ok := best[cand.Family]; !ok || cand.Confidence > existing.Confidence {
+				best[cand.Family] = cand
+			}
+		}
+	}
+	if len(best) == 0 {
+		return nil
+	}
+
+	candidates := make([]Candidate, 0, len(best))
+	for _, c := range best {
+		candidates = append(candidates, c)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+
+	reason := func(c Candidate) string {
+		return fmt.Sprintf("Content analysis suggests %s (%s)", c.Family, c.Reason)
+	}
+
+	if len(candidates) == 1 {
+		c := candidates[0]
+		return []InterpreterChoice{{
+			Source:         "content",
+			Interpreter:    c.Interpreter,
+			Reason:         reason(c),
+			RequiresPrompt: c.Confidence < classifierHighConfidence,
+		}}
+	}
+
+	top := candidates[0].Confidence
+	var choices []InterpreterChoice
+	for _, c := range candidates {
+		if top-c.Confidence > classifierCloseMargin {
+			break
+		}
+		choices = append(choices, InterpreterChoice{
+			Source:         "content",
+			Interpreter:    c.Interpreter,
+			Reason:         reason(c),
+			RequiresPrompt: true,
+		})
+	}
+
+	return choices
+}
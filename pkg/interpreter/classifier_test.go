@@ -0,0 +1,113 @@
+package interpreter
+
+import "testing"
+
+func TestPythonClassifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantNil bool
+	}{
+		{name: "TwoPatterns", content: "import os\nprint('hi')\n", wantNil: false},
+		{name: "OnePatternTooWeak", content: "print('hi')\n", wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pythonClassifier.Classify([]byte(tt.content))
+			if tt.wantNil && got != nil {
+				t.Fatalf("expected nil, got %v", got)
+			}
+			if !tt.wantNil {
+				if len(got) != 1 || got[0].Family != "python" {
+					t.Fatalf("got %v, want a single python candidate", got)
+				}
+			}
+		})
+	}
+}
+
+func TestRubyClassifier(t *testing.T) {
+	content := []byte("require 'json'\ndef foo\n  puts 'hi'\nend\n")
+	got := rubyClassifier.Classify(content)
+	if len(got) != 1 || got[0].Family != "ruby" {
+		t.Fatalf("got %v, want a single ruby candidate", got)
+	}
+}
+
+func TestPHPClassifier(t *testing.T) {
+	got := phpClassifier.Classify([]byte("<?php\necho 'hi';\n"))
+	if len(got) != 1 || got[0].Family != "php" || got[0].Confidence != 0.95 {
+		t.Fatalf("got %v, want a single high-confidence php candidate", got)
+	}
+	if got := phpClassifier.Classify([]byte("no tag here")); got != nil {
+		t.Fatalf("expected nil without a <?php tag, got %v", got)
+	}
+}
+
+func TestNodeClassifier(t *testing.T) {
+	content := []byte("function foo(x) {\n  const y = x + 1;\n  return y;\n}\n")
+	got := nodeClassifier.Classify(content)
+	if len(got) != 1 || got[0].Family != "javascript" {
+		t.Fatalf("got %v, want a single javascript candidate", got)
+	}
+}
+
+func TestLuaClassifier(t *testing.T) {
+	got := luaClassifier.Classify([]byte("local function foo()\nend\n"))
+	if len(got) != 1 || got[0].Family != "lua" {
+		t.Fatalf("got %v, want a single lua candidate", got)
+	}
+	if got := luaClassifier.Classify([]byte("local x = 1\n")); got != nil {
+		t.Fatalf("expected nil without both local and function, got %v", got)
+	}
+}
+
+func TestShellReExecClassifier(t *testing.T) {
+	content := []byte("#!/bin/sh\nexec perl -x $0 \"$@\"\n")
+	got := shellReExecClassifier.Classify(content)
+	if len(got) != 1 || got[0].Family != "perl" {
+		t.Fatalf("got %v, want a single perl candidate", got)
+	}
+}
+
+func TestClassifyChoicesSingleHighConfidence(t *testing.T) {
+	content := []byte("<?php\necho 'hi';\n")
+	choices := classifyChoices(content)
+	if len(choices) != 1 {
+		t.Fatalf("expected 1 choice, got %d: %v", len(choices), choices)
+	}
+	if choices[0].Interpreter != "php" || choices[0].RequiresPrompt {
+		t.Errorf("got %+v, want an automatic php choice", choices[0])
+	}
+}
+
+func TestClassifyChoicesLowConfidenceStillPrompts(t *testing.T) {
+	content := []byte("local x = 1\nlocal function foo()\nend\n")
+	choices := classifyChoices(content)
+	if len(choices) != 1 {
+		t.Fatalf("expected 1 choice, got %d: %v", len(choices), choices)
+	}
+	if !choices[0].RequiresPrompt {
+		t.Errorf("lua's 0.6 confidence is below classifierHighConfidence, expected RequiresPrompt")
+	}
+}
+
+func TestClassifyChoicesNoMatch(t *testing.T) {
+	if got := classifyChoices([]byte("just some plain text\n")); got != nil {
+		t.Errorf("expected nil choices for unrecognizable content, got %v", got)
+	}
+}
+
+func TestRegisterClassifier(t *testing.T) {
+	original := classifiers
+	defer func() { classifiers = original }()
+
+	RegisterClassifier(ClassifierFunc(func(content []byte) []Candidate {
+		return []Candidate{{Family: "cobol", Interpreter: "cobc", Confidence: 0.99, Reason: "test classifier"}}
+	}))
+
+	choices := classifyChoices([]byte("IDENTIFICATION DIVISION.\n"))
+	if len(choices) != 1 || choices[0].Interpreter != "cobc" {
+		t.Fatalf("got %v, want the registered cobol classifier's choice", choices)
+	}
+}
@@ -0,0 +1,118 @@
+package interpreter
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// sniffPrefixBytes bounds how much of scriptContent the content sniffer
+// inspects, so detection stays cheap even for large files.
+const sniffPrefixBytes = 8192
+
+// ContentRule is a single content-sniffing signature: a language's Match
+// predicate is checked against a bounded prefix of the script, and
+// Specificity ranks this rule against other rules that also match (higher
+// fires first). Interpreter is only ever informative here - it still goes
+// through the usual resolveInterpreter/PATH lookup, and the resulting
+// choice always requires a prompt since sniffing is heuristic.
+type ContentRule struct {
+	Name        string
+	Interpreter string
+	Args        []string
+	Specificity int
+	Match       func([]byte) bool
+}
+
+// ContentRules is the default table of content-sniffing signatures, tried
+// as a last resort when neither a usable shebang nor a recognized extension
+// is available. Users and tests can append to this slice to register
+// additional detectors.
+var ContentRules = []ContentRule{
+	{
+		Name:        "php",
+		Interpreter: "php",
+		Specificity: 10,
+		Match: func(b []byte) bool {
+			return bytes.Contains(b, []byte("<?php"))
+		},
+	},
+	{
+		Name:        "ansible-playbook",
+		Interpreter: "ansible-playbook",
+		Specificity: 9,
+		Match: func(b []byte) bool {
+			return bytes.HasPrefix(bytes.TrimLeft(b, " \t\r\n"), []byte("---")) && bytes.Contains(b, []byte("hosts:"))
+		},
+	},
+	{
+		Name:        "go",
+		Interpreter: "go",
+		Args:        []string{"run"},
+		Specificity: 8,
+		Match: func(b []byte) bool {
+			return bytes.Contains(b, []byte("package ")) && bytes.Contains(b, []byte("import ("))
+		},
+	},
+	{
+		Name:        "scheme",
+		Interpreter: "scheme",
+		Specificity: 7,
+		Match: func(b []byte) bool {
+			return bytes.Contains(b, []byte("(defun")) || bytes.Contains(b, []byte("(define"))
+		},
+	},
+	{
+		Name:        "c",
+		Interpreter: "cc",
+		Specificity: 6,
+		Match: func(b []byte) bool {
+			return bytes.Contains(b, []byte("#include")) && bytes.Contains(b, []byte("int main"))
+		},
+	},
+	{
+		Name:        "perl",
+		Interpreter: "perl",
+		Specificity: 5,
+		Match: func(b []byte) bool {
+			return bytes.Contains(b, []byte("use strict;"))
+		},
+	},
+}
+
+// sniffContent matches scriptContent against ContentRules and returns one
+// InterpreterChoice per hit, ranked most-specific first. Each choice
+// requires a prompt, since sniffing is heuristic rather than authoritative.
+func sniffContent(scriptContent []byte) []InterpreterChoice {
+	prefix := scriptContent
+	if len(prefix) > sniffPrefixBytes {
+		prefix = prefix[:sniffPrefixBytes]
+	}
+
+	var matched []ContentRule
+	for _, rule := range ContentRules {
+		if rule.Match(prefix) {
+			matched = append(matched, rule)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].Specificity > matched[j].Specificity
+	})
+
+	choices := make([]InterpreterChoice, len(matched))
+	for i, rule := range matched {
+		choices[i] = InterpreterChoice{
+			Source:         "content",
+			Interpreter:    rule.Interpreter,
+			Args:           rule.Args,
+			Reason:         fmt.Sprintf("Content matches %s signature", rule.Name),
+			RequiresPrompt: true,
+		}
+	}
+
+	return choices
+}
@@ -0,0 +1,49 @@
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/sfkleach/scriptman/pkg/interpreter"
+)
+
+// TestDetermineInterpreterChoices_GoContentSniff exercises the "go" content
+// rule, which must resolve to a real PATH executable ("go") plus its own
+// "run" argument rather than the unresolvable two-word string "go run".
+func TestDetermineInterpreterChoices_GoContentSniff(t *testing.T) {
+	content := []byte("package main\n\nimport (\n\t\"fmt\"\n)\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n")
+	result := DetermineInterpreterChoices("tool", content, "", false)
+
+	if result.Error != nil {
+		t.Fatalf("unexpected error: %v", result.Error)
+	}
+	if len(result.Choices) == 0 {
+		t.Fatalf("expected at least 1 choice, got 0")
+	}
+
+	choice := result.Choices[0]
+	if choice.Source != "content" {
+		t.Fatalf("expected source 'content', got %s", choice.Source)
+	}
+	if choice.Interpreter != "go" {
+		t.Errorf("expected interpreter 'go', got %q", choice.Interpreter)
+	}
+	if len(choice.Args) != 1 || choice.Args[0] != "run" {
+		t.Errorf("expected args [\"run\"], got %v", choice.Args)
+	}
+}
+
+// TestContentRules_GoRuleHasNoMultiWordInterpreter guards against the
+// "go run" regression: Interpreter must always be a single PATH-resolvable
+// token, with any additional words carried in Args instead.
+func TestContentRules_GoRuleHasNoMultiWordInterpreter(t *testing.T) {
+	for _, rule := range interpreter.ContentRules {
+		if rule.Name == "go" {
+			if rule.Interpreter != "go" {
+				t.Errorf("go rule Interpreter = %q, want %q", rule.Interpreter, "go")
+			}
+			if len(rule.Args) != 1 || rule.Args[0] != "run" {
+				t.Errorf("go rule Args = %v, want [\"run\"]", rule.Args)
+			}
+		}
+	}
+}
@@ -3,12 +3,17 @@ package interpreter
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/sfkleach/scriptman/pkg/config"
+	"github.com/sfkleach/scriptman/pkg/platform"
 )
 
 // ExtensionMap maps file extensions to alternative interpreters in priority order.
@@ -48,20 +53,44 @@ var interpreterFamilies = map[string]string{
 
 // shebangInfo contains parsed shebang information.
 type shebangInfo struct {
-	interpreter string   // The interpreter name (e.g., "python3")
-	arguments   []string // Any arguments passed to the interpreter
-	usesEnv     bool     // Whether it uses #!/usr/bin/env form
-	fullLine    string   // The complete shebang line for reference
+	interpreter       string   // The interpreter name (e.g., "python3")
+	arguments         []string // Any arguments passed to the interpreter
+	usesEnv           bool     // Whether it uses #!/usr/bin/env form
+	fullLine          string   // The complete shebang line for reference
+	versionConstraint string   // e.g. ">=3.10,<4", parsed from an inline "env -S name>=ver" form or a "# scriptman:" directive comment; empty if none
 }
 
 // InterpreterChoice represents a possible interpreter choice with reasoning.
 type InterpreterChoice struct {
-	Source         string   // "explicit", "shebang", "extension", "extension-alternatives"
-	Interpreter    string   // The interpreter name or path
-	Alternatives   []string // Alternative interpreters (for extension-based)
-	UseShebang     bool     // If true, use shebang line verbatim
-	Reason         string   // Human-readable reason for this choice
-	RequiresPrompt bool     // Whether this choice requires user confirmation
+	Source         string   `json:"source"`                    // "explicit", "metadata", "policy", "shebang", "extension", "extension-alternatives", "content"
+	Interpreter    string   `json:"interpreter"`               // The interpreter name or path
+	Alternatives   []string `json:"alternatives,omitempty"`    // Alternative interpreters (for extension-based)
+	Args           []string `json:"args,omitempty"`            // Extra arguments associated with the choice (e.g. from a policy rule)
+	UseShebang     bool     `json:"use_shebang,omitempty"`     // If true, use shebang line verbatim
+	Reason         string   `json:"reason"`                    // Human-readable reason for this choice
+	RequiresPrompt bool     `json:"requires_prompt,omitempty"` // Whether this choice requires user confirmation
+
+	// VersionConstraint is a semver-style constraint (e.g. ">=3.10,<4")
+	// parsed from the shebang; when set, resolveChoice resolves it against
+	// interpreters discoverable on PATH instead of using Interpreter as a
+	// literal executable name. See ResolveVersionedInterpreter.
+	VersionConstraint string `json:"version_constraint,omitempty"`
+
+	// ScriptFilename, if non-empty, is the filename scriptman should give
+	// the locally-saved script instead of keeping the source's original
+	// extension (e.g. ".bat" for cmd, ".ps1" for PowerShell). Populated by
+	// resolveChoice; see platform.ScriptFilename.
+	ScriptFilename string `json:"script_filename,omitempty"`
+
+	// ForcedExtension, if non-empty, is an "extension:" value from a
+	// scriptman metadata block (see ScriptMetadata) and overrides whatever
+	// ScriptFilename platform.ScriptFilename would otherwise have derived.
+	ForcedExtension string `json:"forced_extension,omitempty"`
+
+	// IncludeShebangLine reports whether scriptman should preserve a
+	// leading "#!" line in the saved script. Populated by resolveChoice;
+	// see platform.IncludeShebangLine.
+	IncludeShebangLine bool `json:"include_shebang_line,omitempty"`
 }
 
 // DecisionResult contains the interpreter choices determined for a script.
@@ -70,24 +99,56 @@ type DecisionResult struct {
 	Error   error               // Error if no valid choices available
 }
 
+// decisionResultJSON is DecisionResult's wire shape: Error flattened to a
+// plain string, since the error interface has no exported fields for
+// encoding/json to marshal on its own.
+type decisionResultJSON struct {
+	Choices []InterpreterChoice `json:"choices,omitempty"`
+	Error   string              `json:"error,omitempty"`
+}
+
+// MarshalJSON lets DecisionResult serialize directly, for JSONPrompter and
+// `scriptman inspect --json`.
+func (d DecisionResult) MarshalJSON() ([]byte, error) {
+	wire := decisionResultJSON{Choices: d.Choices}
+	if d.Error != nil {
+		wire.Error = d.Error.Error()
+	}
+	return json.Marshal(wire)
+}
+
 // Detect determines the appropriate interpreter for a script.
 // Priority:
 // 1. Explicit interpreter parameter (if provided)
-// 2. Shebang line with consistency checking and user prompting (unless trustShebang is true)
-// 3. File extension mapping (checks which alternative exists on PATH)
-// 4. Error if none can be determined
-func Detect(scriptPath string, scriptContent []byte, explicitInterpreter string, trustShebang bool) (string, string, error) {
+// 2. A scriptman metadata block immediately after the shebang (see ScriptMetadata)
+// 3. A matching rule in the interpreter policy file (see DecisionInput)
+// 4. Shebang line with consistency checking and user prompting (unless trustShebang is true)
+// 5. File extension mapping (checks which alternative exists on PATH)
+// 6. Content classification and sniffing (see RegisterClassifier)
+// 7. Error if none can be determined
+//
+// Alongside the resolved interpreter path and an optional warning, it
+// returns the extra interpreter arguments associated with the winning
+// choice (from a policy rule's "Args" directive or a metadata block's
+// "interpreter-args" key - see PolicyRule.Args and
+// ScriptMetadata.InterpreterArgs), and two platform hints for how the
+// script should be saved to disk (see pkg/platform): scriptFilename, which
+// if non-empty replaces the source's extension (".bat" for cmd, ".ps1" for
+// PowerShell), and includeShebangLine, which is false when the resolved
+// interpreter would choke on a leading "#!" line.
+func Detect(scriptPath string, scriptContent []byte, explicitInterpreter string, trustShebang bool) (string, []string, string, string, bool, error) {
 	// Get decision result.
-	decision := DetermineInterpreterChoices(scriptPath, scriptContent, explicitInterpreter, trustShebang)
+	input := NewDecisionInput(scriptPath, scriptContent, explicitInterpreter, trustShebang)
+	decision := input.DetermineInterpreterChoices()
 
 	// Handle error case.
 	if decision.Error != nil {
-		return "", "", decision.Error
+		return "", nil, "", "", false, decision.Error
 	}
 
 	// Handle no choices (shouldn't happen but defensive).
 	if len(decision.Choices) == 0 {
-		return "", "", fmt.Errorf("internal error: no choices determined for %s", scriptPath)
+		return "", nil, "", "", false, fmt.Errorf("internal error: no choices determined for %s", scriptPath)
 	}
 
 	// Single choice - automatic decision.
@@ -98,32 +159,65 @@ func Detect(scriptPath string, scriptContent []byte, explicitInterpreter string,
 		if choice.RequiresPrompt {
 			approved := promptSingleChoice(choice)
 			if !approved {
-				return "", "", fmt.Errorf("installation aborted by user")
+				return "", nil, "", "", false, fmt.Errorf("installation aborted by user")
 			}
 		}
 
-		return resolveChoice(choice)
+		return resolveChoice(choice, scriptPath)
 	}
 
 	// Multiple choices - need user input.
 	selectedChoice := promptMultipleChoices(decision.Choices)
 	if selectedChoice == nil {
-		return "", "", fmt.Errorf("installation aborted by user")
+		return "", nil, "", "", false, fmt.Errorf("installation aborted by user")
 	}
 
-	return resolveChoice(*selectedChoice)
+	return resolveChoice(*selectedChoice, scriptPath)
 }
 
-// resolveChoice converts an InterpreterChoice into an actual interpreter path.
-func resolveChoice(choice InterpreterChoice) (string, string, error) {
-	// Handle explicit interpreter.
-	if choice.Source == "explicit" {
-		path, err := resolveInterpreter(choice.Interpreter)
+// resolveChoice converts an InterpreterChoice into an actual interpreter
+// path, plus its associated Args and the platform.ScriptFilename/
+// platform.IncludeShebangLine hints for the interpreter it names.
+func resolveChoice(choice InterpreterChoice, scriptPath string) (string, []string, string, string, bool, error) {
+	path, warning, err := resolveChoicePath(choice)
+	if err != nil {
+		return "", nil, "", "", false, err
+	}
+
+	recipe := strings.TrimSuffix(filepath.Base(scriptPath), filepath.Ext(scriptPath))
+	if choice.ForcedExtension != "" {
+		choice.ScriptFilename = recipe + "." + choice.ForcedExtension
+	} else {
+		choice.ScriptFilename = platform.ScriptFilename(recipe, choice.Interpreter)
+	}
+	choice.IncludeShebangLine = platform.IncludeShebangLine(choice.Interpreter)
+
+	return path, choice.Args, warning, choice.ScriptFilename, choice.IncludeShebangLine, nil
+}
+
+// resolveChoicePath converts an InterpreterChoice into an actual interpreter path.
+func resolveChoicePath(choice InterpreterChoice) (string, string, error) {
+	// Handle explicit interpreter, metadata-block, policy-file, and
+	// content-sniffed matches the same way: each names a specific
+	// interpreter that should be used verbatim, honoring a version
+	// constraint if one was set (metadata's "min-version").
+	if choice.Source == "explicit" || choice.Source == "metadata" || choice.Source == "policy" || choice.Source == "content" {
+		if choice.VersionConstraint != "" {
+			path, err := ResolveFamilyInterpreter(choice.Interpreter, choice.VersionConstraint)
+			return path, "", err
+		}
+		path, err := resolveNamedInterpreter(choice.Interpreter)
 		return path, "", err
 	}
 
-	// Handle shebang (use verbatim).
+	// Handle shebang (use verbatim, or resolve a version constraint if one
+	// was parsed from the shebang token, its arguments, or a directive
+	// comment - see splitVersionedInterpreter and finalizeShebangInfo).
 	if choice.UseShebang || choice.Source == "shebang" {
+		if choice.VersionConstraint != "" {
+			path, err := ResolveFamilyInterpreter(choice.Interpreter, choice.VersionConstraint)
+			return path, "", err
+		}
 		path, err := resolveInterpreter(choice.Interpreter)
 		return path, "", err
 	}
@@ -136,6 +230,26 @@ func resolveChoice(choice InterpreterChoice) (string, string, error) {
 	return "", "", fmt.Errorf("internal error: unknown choice source %s", choice.Source)
 }
 
+// DecisionInput bundles everything DetermineInterpreterChoices needs to
+// decide on an interpreter for a single script.
+type DecisionInput struct {
+	scriptPath          string
+	scriptContent       []byte
+	explicitInterpreter string
+	trustShebang        bool
+}
+
+// NewDecisionInput builds a DecisionInput for a single interpreter-detection
+// call.
+func NewDecisionInput(scriptPath string, scriptContent []byte, explicitInterpreter string, trustShebang bool) *DecisionInput {
+	return &DecisionInput{
+		scriptPath:          scriptPath,
+		scriptContent:       scriptContent,
+		explicitInterpreter: explicitInterpreter,
+		trustShebang:        trustShebang,
+	}
+}
+
 // DetermineInterpreterChoices analyzes a script and returns possible interpreter choices.
 // Returns a DecisionResult with:
 //   - 0 choices + error: Cannot determine interpreter (error case)
@@ -143,7 +257,9 @@ func resolveChoice(choice InterpreterChoice) (string, string, error) {
 //   - 2 choices: Ambiguous, requires user input
 //
 // If trustShebang is true, shebang lines are used without consistency checks or prompts.
-func DetermineInterpreterChoices(scriptPath string, scriptContent []byte, explicitInterpreter string, trustShebang bool) DecisionResult {
+func (d *DecisionInput) DetermineInterpreterChoices() DecisionResult {
+	scriptPath, scriptContent, explicitInterpreter, trustShebang := d.scriptPath, d.scriptContent, d.explicitInterpreter, d.trustShebang
+
 	shebang := parseShebang(scriptContent)
 	ext := filepath.Ext(scriptPath)
 
@@ -158,11 +274,66 @@ func DetermineInterpreterChoices(scriptPath string, scriptContent []byte, explic
 		}
 	}
 
-	// Priority 2: Shebang exists - complex logic (or trust it directly).
+	// Priority 2: A scriptman metadata block immediately after the shebang
+	// (see ScriptMetadata) - the script author's own explicit declaration,
+	// which outranks the policy file, shebang inference, and extension
+	// mapping, but never an explicit --interpreter.
+	if meta, err := parseScriptMetadata(scriptContent); err != nil {
+		return DecisionResult{Error: err}
+	} else if meta != nil {
+		if meta.OS != "" && !osMatches(meta.OS) {
+			return DecisionResult{Error: fmt.Errorf("script declares os:%s, but this host is %s", meta.OS, currentOS())}
+		}
+
+		choice := InterpreterChoice{
+			Source:          "metadata",
+			Interpreter:     meta.Interpreter,
+			Args:            meta.InterpreterArgs,
+			ForcedExtension: meta.Extension,
+			Reason:          "Declared by scriptman metadata block",
+		}
+		if meta.Confirm != "" {
+			choice.RequiresPrompt = true
+			choice.Reason = meta.Confirm
+		}
+		if meta.MinVersion != "" {
+			m := inlineConstraintRe.FindStringSubmatch(meta.MinVersion)
+			if m == nil {
+				return DecisionResult{Error: fmt.Errorf("invalid min-version %q in scriptman metadata (expected e.g. python>=3.10)", meta.MinVersion)}
+			}
+			if choice.Interpreter == "" {
+				choice.Interpreter = m[1]
+			}
+			choice.VersionConstraint = m[2]
+		}
+		if choice.Interpreter == "" {
+			return DecisionResult{Error: fmt.Errorf("scriptman metadata block must set interpreter or min-version")}
+		}
+
+		return DecisionResult{Choices: []InterpreterChoice{choice}}
+	}
+
+	// Priority 3: A matching rule in the interpreter policy file, loaded once
+	// for this invocation. First match wins, like ssh_config.
+	if rules, err := LoadInterpreterPolicy(config.GetDefaultInterpreterPolicyPath()); err == nil {
+		if rule := firstPolicyMatch(rules, scriptPath, scriptContent, shebang); rule != nil {
+			return DecisionResult{
+				Choices: []InterpreterChoice{{
+					Source:         "policy",
+					Interpreter:    rule.Interpreter,
+					Args:           rule.Args,
+					Reason:         fmt.Sprintf("Matched policy rule (%s %s)", rule.PredicateType, rule.Pattern),
+					RequiresPrompt: !rule.Trust,
+				}},
+			}
+		}
+	}
+
+	// Priority 4: Shebang exists - complex logic (or trust it directly).
 	if shebang != nil {
 		if trustShebang {
 			// Trust shebang without any checks.
-			return DecisionResult{
+			result := DecisionResult{
 				Choices: []InterpreterChoice{{
 					Source:      "shebang",
 					Interpreter: shebang.interpreter,
@@ -170,11 +341,12 @@ func DetermineInterpreterChoices(scriptPath string, scriptContent []byte, explic
 					Reason:      "Trusting shebang via --trust-shebang flag",
 				}},
 			}
+			return applyVersionConstraint(result, shebang)
 		}
-		return determineWithShebang(scriptPath, ext, shebang)
+		return applyVersionConstraint(determineWithShebang(scriptPath, ext, shebang), shebang)
 	}
 
-	// Priority 3: Extension mapping only (no shebang).
+	// Priority 5: Extension mapping only (no shebang).
 	if alternatives, ok := ExtensionMap[ext]; ok {
 		return DecisionResult{
 			Choices: []InterpreterChoice{{
@@ -185,7 +357,19 @@ func DetermineInterpreterChoices(scriptPath string, scriptContent []byte, explic
 		}
 	}
 
-	// Priority 4: No information available.
+	// Priority 6: Content classification and sniffing, for scripts with
+	// neither a usable shebang nor a recognized extension. Tried as a
+	// strategy chain: the pluggable classifiers (see RegisterClassifier)
+	// run first, and the built-in signature table is a fallback for
+	// languages no registered classifier recognizes.
+	if choices := classifyChoices(scriptContent); len(choices) > 0 {
+		return DecisionResult{Choices: choices}
+	}
+	if choices := sniffContent(scriptContent); len(choices) > 0 {
+		return DecisionResult{Choices: choices}
+	}
+
+	// Priority 7: No information available.
 	return DecisionResult{
 		Error: fmt.Errorf("could not determine interpreter for %s (no --interpreter, no shebang, extension %s not recognized)", scriptPath, ext),
 	}
@@ -346,17 +530,25 @@ func parseShebang(content []byte) *shebangInfo {
 		fullLine: fullLine,
 	}
 
-	// Handle "/usr/bin/env interpreter [args...]" form.
+	// Handle "/usr/bin/env interpreter [args...]" form, including env's own
+	// "-S" flag for splitting a single shebang argument into a command line.
 	if strings.Contains(line, "/env") {
 		parts := strings.Fields(line)
 		for i, part := range parts {
 			if strings.HasSuffix(part, "/env") && i+1 < len(parts) {
+				rest := parts[i+1:]
+				if len(rest) > 0 && rest[0] == "-S" {
+					rest = rest[1:]
+				}
+				if len(rest) == 0 {
+					return nil
+				}
 				info.usesEnv = true
-				info.interpreter = parts[i+1]
-				if i+2 < len(parts) {
-					info.arguments = parts[i+2:]
+				info.interpreter = rest[0]
+				if len(rest) > 1 {
+					info.arguments = rest[1:]
 				}
-				return info
+				return finalizeShebangInfo(info, content)
 			}
 		}
 	}
@@ -364,16 +556,55 @@ func parseShebang(content []byte) *shebangInfo {
 	// Handle direct path form: extract basename and arguments.
 	parts := strings.Fields(line)
 	if len(parts) > 0 {
-		info.interpreter = filepath.Base(parts[0])
+		info.interpreter = interpreterBaseName(parts[0])
 		if len(parts) > 1 {
 			info.arguments = parts[1:]
 		}
-		return info
+		return finalizeShebangInfo(info, content)
 	}
 
 	return nil
 }
 
+// interpreterBaseName strips a path prefix from a shebang's interpreter
+// token, recognizing both POSIX and Windows-style separators so a shebang
+// like "#!C:\Python39\python.exe" resolves the same way as
+// "#!/usr/bin/python".
+func interpreterBaseName(path string) string {
+	if idx := strings.LastIndexAny(path, `/\`); idx >= 0 {
+		return path[idx+1:]
+	}
+	return path
+}
+
+// StripShebangLine removes a leading "#!" line from content, if present. It
+// is used when saving a script for an interpreter that doesn't understand
+// shebangs at all (see platform.IncludeShebangLine) - writing one verbatim
+// would otherwise break cmd.exe's or PowerShell's parsing of the generated
+// script file.
+func StripShebangLine(content []byte) []byte {
+	idx := bytes.IndexByte(content, '\n')
+	firstLine := content
+	if idx != -1 {
+		firstLine = content[:idx]
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(firstLine), []byte("#!")) {
+		return content
+	}
+	if idx == -1 {
+		return nil
+	}
+	return content[idx+1:]
+}
+
+// Family returns the family name (e.g. "python", "shell", "javascript") for
+// a resolved interpreter path or a bare interpreter name, stripping any
+// directory component first. Used by the lint gate (pkg/linter) to dispatch
+// to the right tool for a given interpreter.
+func Family(interpreterPath string) string {
+	return getInterpreterFamily(interpreterBaseName(interpreterPath))
+}
+
 // getInterpreterFamily returns the family name for an interpreter.
 // Strips version numbers and normalizes names.
 func getInterpreterFamily(interpreter string) string {
@@ -418,126 +649,21 @@ func resolveInterpreterWithoutCheck(name string) (string, error) {
 	return filepath.Join("/usr/bin", name), nil
 }
 
-// promptSingleChoice prompts the user for a single choice that requires confirmation.
+// promptSingleChoice asks ActivePrompter to confirm a single choice that
+// requires it, returning false if declined or aborted.
 func promptSingleChoice(choice InterpreterChoice) bool {
-	fmt.Fprintf(os.Stderr, "\n%s\n", choice.Reason)
-	fmt.Fprintf(os.Stderr, "Options:\n")
-	fmt.Fprintf(os.Stderr, "  1. Proceed\n")
-	fmt.Fprintf(os.Stderr, "  2. Abort installation\n")
-
-	selected := promptChoice("[1]", []string{"1", "2"})
-	return selected == "1"
+	_, err := ActivePrompter.Ask(context.Background(), []InterpreterChoice{choice})
+	return err == nil
 }
 
-// promptMultipleChoices prompts the user to select from multiple choices.
-// Returns the selected choice or nil if aborted.
+// promptMultipleChoices asks ActivePrompter to pick one of several choices.
+// Returns the selected choice, or nil if declined or aborted.
 func promptMultipleChoices(choices []InterpreterChoice) *InterpreterChoice {
-	fmt.Fprintf(os.Stderr, "\nMultiple interpreter options available:\n")
-	for i, choice := range choices {
-		fmt.Fprintf(os.Stderr, "  %d. %s\n", i+1, choice.Reason)
-	}
-	fmt.Fprintf(os.Stderr, "  %d. Abort installation\n", len(choices)+1)
-
-	validChoices := make([]string, len(choices)+1)
-	for i := 0; i < len(choices); i++ {
-		validChoices[i] = fmt.Sprintf("%d", i+1)
-	}
-	validChoices[len(choices)] = fmt.Sprintf("%d", len(choices)+1)
-
-	selected := promptChoice("[1]", validChoices)
-	idx := 0
-	fmt.Sscanf(selected, "%d", &idx)
-
-	if idx < 1 || idx > len(choices) {
-		return nil // Abort
-	}
-
-	return &choices[idx-1]
-}
-
-// promptShebangWithArguments is kept for backward compatibility but simplified.
-func promptShebangWithArguments(shebang *shebangInfo) (bool, bool) {
-	fmt.Fprintf(os.Stderr, "\nScript has shebang: %s\n", shebang.fullLine)
-	fmt.Fprintf(os.Stderr, "This uses interpreter arguments: %s\n", strings.Join(shebang.arguments, " "))
-	fmt.Fprintf(os.Stderr, "Options:\n")
-	fmt.Fprintf(os.Stderr, "  1. Use our configured interpreter without arguments (recommended)\n")
-	fmt.Fprintf(os.Stderr, "  2. Copy shebang verbatim (may be system-specific)\n")
-	fmt.Fprintf(os.Stderr, "  3. Abort installation\n")
-
-	choice := promptChoice("[1]", []string{"1", "2", "3"})
-	switch choice {
-	case "1":
-		return true, false // Use our interpreter
-	case "2":
-		return true, true // Use shebang
-	case "3":
-		return false, false // Abort
-	default:
-		return true, false // Default to option 1
-	}
-}
-
-// promptNoExtension is kept for backward compatibility but simplified.
-func promptNoExtension(shebang *shebangInfo) (bool, bool) {
-	fmt.Fprintf(os.Stderr, "\nScript has no file extension.\n")
-	fmt.Fprintf(os.Stderr, "Shebang line: %s\n", shebang.fullLine)
-	fmt.Fprintf(os.Stderr, "Options:\n")
-	fmt.Fprintf(os.Stderr, "  1. Use shebang interpreter (recommended)\n")
-	fmt.Fprintf(os.Stderr, "  2. Abort installation\n")
-
-	choice := promptChoice("[1]", []string{"1", "2"})
-	switch choice {
-	case "1":
-		return true, true // Use shebang
-	case "2":
-		return false, false // Abort
-	default:
-		return true, true // Default to option 1
-	}
-}
-
-// promptUnrecognizedExtension is kept for backward compatibility but simplified.
-func promptUnrecognizedExtension(scriptPath string, shebang *shebangInfo) (bool, bool) {
-	ext := filepath.Ext(scriptPath)
-	fmt.Fprintf(os.Stderr, "\nFile extension %s is not recognized.\n", ext)
-	fmt.Fprintf(os.Stderr, "Shebang line: %s\n", shebang.fullLine)
-	fmt.Fprintf(os.Stderr, "Options:\n")
-	fmt.Fprintf(os.Stderr, "  1. Use shebang interpreter (recommended)\n")
-	fmt.Fprintf(os.Stderr, "  2. Abort installation\n")
-
-	choice := promptChoice("[1]", []string{"1", "2"})
-	switch choice {
-	case "1":
-		return true, true // Use shebang
-	case "2":
-		return false, false // Abort
-	default:
-		return true, true // Default to option 1
-	}
-}
-
-// promptInconsistent is kept for backward compatibility but simplified.
-func promptInconsistent(scriptPath string, shebang *shebangInfo, alternatives []string) (bool, bool) {
-	ext := filepath.Ext(scriptPath)
-	fmt.Fprintf(os.Stderr, "\nInterpreter mismatch detected:\n")
-	fmt.Fprintf(os.Stderr, "  Shebang: %s\n", shebang.fullLine)
-	fmt.Fprintf(os.Stderr, "  Extension %s suggests: %s\n", ext, strings.Join(alternatives, " or "))
-	fmt.Fprintf(os.Stderr, "Options:\n")
-	fmt.Fprintf(os.Stderr, "  1. Use extension-based interpreter (recommended)\n")
-	fmt.Fprintf(os.Stderr, "  2. Use shebang interpreter\n")
-	fmt.Fprintf(os.Stderr, "  3. Abort installation\n")
-
-	choice := promptChoice("[1]", []string{"1", "2", "3"})
-	switch choice {
-	case "1":
-		return true, false // Use extension
-	case "2":
-		return true, true // Use shebang
-	case "3":
-		return false, false // Abort
-	default:
-		return true, false // Default to option 1
+	idx, err := ActivePrompter.Ask(context.Background(), choices)
+	if err != nil {
+		return nil
 	}
+	return &choices[idx]
 }
 
 // promptChoice displays a prompt and reads user input.
@@ -0,0 +1,136 @@
+package interpreter
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// ScriptMetadata is a scriptman attribute block a script can place
+// immediately after its shebang to declare its own installation behavior
+// explicitly, mirroring `just`'s recipe attributes ([extension('EXT')],
+// [confirm('PROMPT')], [linux], [macos]).
+type ScriptMetadata struct {
+	Interpreter     string
+	InterpreterArgs []string
+	Extension       string // forces the saved script's filename suffix; the wrapper's name is not changed
+	OS              string // "linux", "macos", or "windows"; empty means any
+	Confirm         string // custom prompt text; forces RequiresPrompt
+	MinVersion      string // e.g. "python>=3.10"
+}
+
+// metadataKeys are the only keys parseScriptMetadata accepts; anything else
+// is a typo and rejected outright rather than silently ignored.
+var metadataKeys = map[string]bool{
+	"interpreter":      true,
+	"interpreter-args": true,
+	"extension":        true,
+	"os":               true,
+	"confirm":          true,
+	"min-version":      true,
+}
+
+// directiveLineRe matches a single "#scriptman:key: value" metadata line.
+var directiveLineRe = regexp.MustCompile(`^#\s*scriptman:\s*([a-z-]+):\s*(.*)$`)
+
+const metadataFenceStart = "# --- scriptman ---"
+const metadataFenceEnd = "# --- end ---"
+
+// parseScriptMetadata scans the lines immediately after a script's shebang
+// for a scriptman metadata block: either one or more consecutive
+// "#scriptman:key: value" lines, or a fenced "# --- scriptman ---" /
+// "# --- end ---" block of "# key: value" lines. Returns nil, nil if the
+// script has no shebang or no such block follows it. An unknown key is an
+// error, so a typo in a key isn't silently ignored.
+func parseScriptMetadata(content []byte) (*ScriptMetadata, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	if !scanner.Scan() || !strings.HasPrefix(strings.TrimSpace(scanner.Text()), "#!") {
+		return nil, nil
+	}
+
+	type pair struct{ key, value string }
+	var pairs []pair
+	inFence := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if !inFence {
+			if trimmed == metadataFenceStart {
+				inFence = true
+				continue
+			}
+			if m := directiveLineRe.FindStringSubmatch(trimmed); m != nil {
+				pairs = append(pairs, pair{m[1], strings.TrimSpace(m[2])})
+				continue
+			}
+			break // first non-matching line ends the metadata block
+		}
+
+		if trimmed == metadataFenceEnd {
+			break
+		}
+		key, value, ok := parseFenceLine(trimmed)
+		if !ok {
+			return nil, fmt.Errorf("malformed scriptman metadata line: %q", line)
+		}
+		pairs = append(pairs, pair{key, value})
+	}
+
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	meta := &ScriptMetadata{}
+	for _, p := range pairs {
+		if !metadataKeys[p.key] {
+			return nil, fmt.Errorf("unknown scriptman metadata key %q (expected one of interpreter, interpreter-args, extension, os, confirm, min-version)", p.key)
+		}
+		switch p.key {
+		case "interpreter":
+			meta.Interpreter = p.value
+		case "interpreter-args":
+			meta.InterpreterArgs = strings.Fields(p.value)
+		case "extension":
+			meta.Extension = strings.TrimPrefix(p.value, ".")
+		case "os":
+			meta.OS = p.value
+		case "confirm":
+			meta.Confirm = strings.Trim(p.value, `"`)
+		case "min-version":
+			meta.MinVersion = p.value
+		}
+	}
+
+	return meta, nil
+}
+
+// parseFenceLine splits a "# key: value" line inside a fenced metadata
+// block into its key and value.
+func parseFenceLine(line string) (key, value string, ok bool) {
+	line = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+	idx := strings.Index(line, ":")
+	if idx == -1 {
+		return "", "", false
+	}
+	return strings.TrimSpace(line[:idx]), strings.TrimSpace(line[idx+1:]), true
+}
+
+// currentOS names the running host the way ScriptMetadata.OS expects:
+// "linux", "macos", or "windows".
+func currentOS() string {
+	if runtime.GOOS == "darwin" {
+		return "macos"
+	}
+	return runtime.GOOS
+}
+
+// osMatches reports whether want (as given in an "os:" metadata key)
+// matches the running host.
+func osMatches(want string) bool {
+	return strings.EqualFold(want, currentOS())
+}
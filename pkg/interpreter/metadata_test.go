@@ -0,0 +1,82 @@
+package interpreter
+
+import "testing"
+
+func TestParseScriptMetadataDirectiveLines(t *testing.T) {
+	content := []byte("#!/usr/bin/env bash\n#scriptman:interpreter: python3\n#scriptman:interpreter-args: -u -O\necho hi\n")
+
+	meta, err := parseScriptMetadata(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta == nil {
+		t.Fatalf("expected metadata, got nil")
+	}
+	if meta.Interpreter != "python3" {
+		t.Errorf("Interpreter = %q, want python3", meta.Interpreter)
+	}
+	if len(meta.InterpreterArgs) != 2 || meta.InterpreterArgs[0] != "-u" || meta.InterpreterArgs[1] != "-O" {
+		t.Errorf("InterpreterArgs = %v, want [-u -O]", meta.InterpreterArgs)
+	}
+}
+
+func TestParseScriptMetadataFencedBlock(t *testing.T) {
+	content := []byte("#!/usr/bin/env bash\n# --- scriptman ---\n# interpreter: ruby\n# extension: .rb\n# confirm: \"this modifies prod\"\n# --- end ---\necho hi\n")
+
+	meta, err := parseScriptMetadata(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.Interpreter != "ruby" {
+		t.Errorf("Interpreter = %q, want ruby", meta.Interpreter)
+	}
+	if meta.Extension != "rb" {
+		t.Errorf("Extension = %q, want rb", meta.Extension)
+	}
+	if meta.Confirm != "this modifies prod" {
+		t.Errorf("Confirm = %q, want unquoted prompt text", meta.Confirm)
+	}
+}
+
+func TestParseScriptMetadataNoBlock(t *testing.T) {
+	meta, err := parseScriptMetadata([]byte("#!/usr/bin/env bash\necho hi\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta != nil {
+		t.Errorf("expected nil metadata, got %+v", meta)
+	}
+}
+
+func TestParseScriptMetadataNoShebang(t *testing.T) {
+	meta, err := parseScriptMetadata([]byte("echo hi\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta != nil {
+		t.Errorf("expected nil metadata without a shebang, got %+v", meta)
+	}
+}
+
+func TestParseScriptMetadataUnknownKeyRejected(t *testing.T) {
+	content := []byte("#!/usr/bin/env bash\n#scriptman:bogus: value\necho hi\n")
+	if _, err := parseScriptMetadata(content); err == nil {
+		t.Fatalf("expected error for unknown metadata key")
+	}
+}
+
+func TestParseScriptMetadataMalformedFenceLine(t *testing.T) {
+	content := []byte("#!/usr/bin/env bash\n# --- scriptman ---\n# not a key value line\n# --- end ---\necho hi\n")
+	if _, err := parseScriptMetadata(content); err == nil {
+		t.Fatalf("expected error for a fence line with no colon")
+	}
+}
+
+func TestOSMatches(t *testing.T) {
+	if !osMatches(currentOS()) {
+		t.Errorf("osMatches(currentOS()) = false, want true")
+	}
+	if osMatches("not-a-real-os") {
+		t.Errorf("osMatches(not-a-real-os) = true, want false")
+	}
+}
@@ -0,0 +1,151 @@
+package interpreter
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// PolicyRule is a single ssh_config-style Match block from an interpreter
+// policy file: it pairs a predicate (how to recognize a script) with the
+// interpreter to use and whether matching it should suppress the usual
+// consistency prompts.
+type PolicyRule struct {
+	PredicateType string   // "path", "ext", "shebang", or "content"
+	Pattern       string   // glob (path), exact extension such as ".rb" (ext), or regexp (shebang, content)
+	Interpreter   string   // preferred interpreter, path or PATH-relative name
+	Args          []string // extra arguments to record alongside the choice
+	Trust         bool     // suppress the interactive prompt, like a scoped --trust-shebang
+}
+
+// LoadInterpreterPolicy reads and parses an interpreter policy file. A
+// missing file is not an error: it simply means no rules apply.
+func LoadInterpreterPolicy(path string) ([]PolicyRule, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read interpreter policy %s: %w", path, err)
+	}
+
+	return parsePolicy(data)
+}
+
+// parsePolicy parses the ssh_config-style block syntax:
+//
+//	Match path ~/work/etl/*.py
+//	    Interpreter /opt/py311/bin/python
+//	    Trust yes
+//
+//	Match ext .rb
+//	    Interpreter /usr/local/bin/ruby3.2
+//	    Args --enable=frozen-string-literal
+//
+// Blocks are tried in file order and the first whose Match predicate fires
+// wins, mirroring ssh_config's first-match-wins semantics.
+func parsePolicy(data []byte) ([]PolicyRule, error) {
+	var rules []PolicyRule
+	var current *PolicyRule
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		keyword := strings.ToLower(fields[0])
+		topLevel := raw == line // no leading whitespace: starts a new block
+
+		if topLevel && keyword == "match" {
+			if current != nil {
+				rules = append(rules, *current)
+			}
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("interpreter policy line %d: Match requires a predicate type and pattern", lineNo)
+			}
+			current = &PolicyRule{
+				PredicateType: strings.ToLower(fields[1]),
+				Pattern:       strings.Join(fields[2:], " "),
+			}
+			continue
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("interpreter policy line %d: directive outside of a Match block", lineNo)
+		}
+
+		switch keyword {
+		case "interpreter":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("interpreter policy line %d: Interpreter requires a value", lineNo)
+			}
+			current.Interpreter = fields[1]
+		case "args":
+			current.Args = fields[1:]
+		case "trust":
+			current.Trust = len(fields) > 1 && (strings.EqualFold(fields[1], "yes") || strings.EqualFold(fields[1], "true"))
+		default:
+			return nil, fmt.Errorf("interpreter policy line %d: unknown directive %q", lineNo, fields[0])
+		}
+	}
+	if current != nil {
+		rules = append(rules, *current)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse interpreter policy: %w", err)
+	}
+
+	return rules, nil
+}
+
+// matches reports whether the rule's predicate fires for the given script.
+func (r PolicyRule) matches(scriptPath string, content []byte, shebang *shebangInfo) bool {
+	switch r.PredicateType {
+	case "path":
+		pattern := r.Pattern
+		if strings.HasPrefix(pattern, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				pattern = filepath.Join(home, pattern[2:])
+			}
+		}
+		if ok, _ := filepath.Match(pattern, scriptPath); ok {
+			return true
+		}
+		ok, _ := filepath.Match(pattern, filepath.Base(scriptPath))
+		return ok
+	case "ext":
+		return filepath.Ext(scriptPath) == r.Pattern
+	case "shebang":
+		if shebang == nil {
+			return false
+		}
+		re, err := regexp.Compile(r.Pattern)
+		return err == nil && re.MatchString(shebang.fullLine)
+	case "content":
+		re, err := regexp.Compile(r.Pattern)
+		return err == nil && re.Match(content)
+	default:
+		return false
+	}
+}
+
+// firstPolicyMatch returns the first rule whose predicate fires, or nil if
+// none do.
+func firstPolicyMatch(rules []PolicyRule, scriptPath string, content []byte, shebang *shebangInfo) *PolicyRule {
+	for i := range rules {
+		if rules[i].matches(scriptPath, content, shebang) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
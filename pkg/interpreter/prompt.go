@@ -0,0 +1,141 @@
+package interpreter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrPromptAborted is returned by a Prompter when the user (or a
+// non-interactive policy standing in for one) declines to proceed.
+var ErrPromptAborted = errors.New("aborted by user")
+
+// Prompter asks whoever (or whatever) is driving scriptman to pick one of
+// several InterpreterChoices, returning the chosen index into choices.
+// choices always has at least one element; a single element means "does
+// this one choice need confirming", not "pick among alternatives".
+//
+// See TTYPrompter (the interactive default), NonInteractivePrompter (for
+// CI, where stdin isn't a human), and JSONPrompter (for orchestrators
+// driving scriptman as a subprocess).
+type Prompter interface {
+	Ask(ctx context.Context, choices []InterpreterChoice) (int, error)
+}
+
+// ActivePrompter is the Prompter consulted by Detect whenever a choice
+// requires confirmation or a pick among alternatives. Defaults to
+// TTYPrompter{}; callers that need non-interactive or scripted behavior
+// should set it before calling Detect.
+var ActivePrompter Prompter = TTYPrompter{}
+
+// TTYPrompter is scriptman's traditional interactive behavior: it prints
+// choices to stderr and reads a selection from stdin.
+type TTYPrompter struct{}
+
+func (TTYPrompter) Ask(ctx context.Context, choices []InterpreterChoice) (int, error) {
+	if len(choices) == 1 {
+		choice := choices[0]
+		fmt.Fprintf(os.Stderr, "\n%s\n", choice.Reason)
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "  1. Proceed\n")
+		fmt.Fprintf(os.Stderr, "  2. Abort installation\n")
+
+		if promptChoice("[1]", []string{"1", "2"}) != "1" {
+			return 0, ErrPromptAborted
+		}
+		return 0, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\nMultiple interpreter options available:\n")
+	for i, choice := range choices {
+		fmt.Fprintf(os.Stderr, "  %d. %s\n", i+1, choice.Reason)
+	}
+	fmt.Fprintf(os.Stderr, "  %d. Abort installation\n", len(choices)+1)
+
+	validChoices := make([]string, len(choices)+1)
+	for i := range choices {
+		validChoices[i] = fmt.Sprintf("%d", i+1)
+	}
+	validChoices[len(choices)] = fmt.Sprintf("%d", len(choices)+1)
+
+	idx := 0
+	fmt.Sscanf(promptChoice("[1]", validChoices), "%d", &idx)
+	if idx < 1 || idx > len(choices) {
+		return 0, ErrPromptAborted
+	}
+	return idx - 1, nil
+}
+
+// NonInteractivePrompter serves CI and other unattended runs, where reading
+// from stdin would just hang forever: it fails fast unless told up front
+// how to answer. AssumeYes takes the first (recommended) choice; Default,
+// if non-zero, is a 1-based index into choices to take instead (and wins
+// over AssumeYes if both are set).
+type NonInteractivePrompter struct {
+	AssumeYes bool
+	Default   int
+}
+
+func (p NonInteractivePrompter) Ask(ctx context.Context, choices []InterpreterChoice) (int, error) {
+	if p.Default != 0 {
+		if p.Default < 1 || p.Default > len(choices) {
+			return 0, fmt.Errorf("--default=%d is out of range (have %d choice(s))", p.Default, len(choices))
+		}
+		return p.Default - 1, nil
+	}
+	if p.AssumeYes {
+		return 0, nil
+	}
+	return 0, fmt.Errorf("a prompt is required but input is not interactive (%d option(s) available); rerun with --assume-yes or --default=N: %w", len(choices), ErrPromptAborted)
+}
+
+// JSONPrompter drives a non-interactive decision over a pair of streams
+// instead of a terminal: it writes the full DecisionResult to Out as a
+// single JSON object and reads a single-line `{"choice": N}` reply (N
+// 1-based, matching the choices printed) from In. Out defaults to
+// os.Stdout and In to os.Stdin if left nil, so external orchestrators
+// (installers, package managers) can drive scriptman as a subprocess
+// without parsing human-readable prompts.
+type JSONPrompter struct {
+	Out io.Writer
+	In  io.Reader
+}
+
+func (p JSONPrompter) Ask(ctx context.Context, choices []InterpreterChoice) (int, error) {
+	out := p.Out
+	if out == nil {
+		out = os.Stdout
+	}
+	in := p.In
+	if in == nil {
+		in = os.Stdin
+	}
+
+	if err := json.NewEncoder(out).Encode(DecisionResult{Choices: choices}); err != nil {
+		return 0, fmt.Errorf("failed to write decision for JSON prompt: %w", err)
+	}
+
+	scanner := bufio.NewScanner(in)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return 0, fmt.Errorf("failed to read JSON prompt reply: %w", err)
+		}
+		return 0, fmt.Errorf("no reply read for JSON prompt: %w", ErrPromptAborted)
+	}
+
+	var reply struct {
+		Choice int `json:"choice"`
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &reply); err != nil {
+		return 0, fmt.Errorf("invalid JSON prompt reply: %w", err)
+	}
+	if reply.Choice < 1 || reply.Choice > len(choices) {
+		return 0, fmt.Errorf("reply choice %d is out of range [1,%d]: %w", reply.Choice, len(choices), ErrPromptAborted)
+	}
+
+	return reply.Choice - 1, nil
+}
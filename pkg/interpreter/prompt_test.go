@@ -0,0 +1,100 @@
+package interpreter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testChoices() []InterpreterChoice {
+	return []InterpreterChoice{
+		{Source: "shebang", Interpreter: "python3", Reason: "shebang says python3"},
+		{Source: "extension", Interpreter: "python", Reason: "extension .py maps to python"},
+	}
+}
+
+func TestNonInteractivePrompterDefault(t *testing.T) {
+	p := NonInteractivePrompter{Default: 2}
+	idx, err := p.Ask(context.Background(), testChoices())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if idx != 1 {
+		t.Errorf("idx = %d, want 1", idx)
+	}
+}
+
+func TestNonInteractivePrompterDefaultOutOfRange(t *testing.T) {
+	p := NonInteractivePrompter{Default: 5}
+	if _, err := p.Ask(context.Background(), testChoices()); err == nil {
+		t.Fatalf("expected error for out-of-range --default")
+	}
+}
+
+func TestNonInteractivePrompterAssumeYes(t *testing.T) {
+	p := NonInteractivePrompter{AssumeYes: true}
+	idx, err := p.Ask(context.Background(), testChoices())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if idx != 0 {
+		t.Errorf("idx = %d, want 0", idx)
+	}
+}
+
+func TestNonInteractivePrompterFailsFastWithoutInput(t *testing.T) {
+	p := NonInteractivePrompter{}
+	_, err := p.Ask(context.Background(), testChoices())
+	if !errors.Is(err, ErrPromptAborted) {
+		t.Fatalf("expected ErrPromptAborted, got %v", err)
+	}
+}
+
+func TestJSONPrompterWritesDecisionAndReadsChoice(t *testing.T) {
+	var out bytes.Buffer
+	in := strings.NewReader(`{"choice": 2}` + "\n")
+	p := JSONPrompter{Out: &out, In: in}
+
+	idx, err := p.Ask(context.Background(), testChoices())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if idx != 1 {
+		t.Errorf("idx = %d, want 1", idx)
+	}
+	if !strings.Contains(out.String(), "python3") {
+		t.Errorf("expected written decision to mention python3, got %q", out.String())
+	}
+}
+
+func TestJSONPrompterOutOfRangeChoice(t *testing.T) {
+	var out bytes.Buffer
+	in := strings.NewReader(`{"choice": 99}` + "\n")
+	p := JSONPrompter{Out: &out, In: in}
+
+	if _, err := p.Ask(context.Background(), testChoices()); !errors.Is(err, ErrPromptAborted) {
+		t.Fatalf("expected ErrPromptAborted for out-of-range choice, got %v", err)
+	}
+}
+
+func TestJSONPrompterInvalidReply(t *testing.T) {
+	var out bytes.Buffer
+	in := strings.NewReader("not json\n")
+	p := JSONPrompter{Out: &out, In: in}
+
+	if _, err := p.Ask(context.Background(), testChoices()); err == nil {
+		t.Fatalf("expected error for invalid JSON reply")
+	}
+}
+
+func TestJSONPrompterNoReply(t *testing.T) {
+	var out bytes.Buffer
+	in := strings.NewReader("")
+	p := JSONPrompter{Out: &out, In: in}
+
+	if _, err := p.Ask(context.Background(), testChoices()); !errors.Is(err, ErrPromptAborted) {
+		t.Fatalf("expected ErrPromptAborted for no reply, got %v", err)
+	}
+}
@@ -0,0 +1,359 @@
+package interpreter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sfkleach/scriptman/pkg/config"
+)
+
+// inlineConstraintRe matches an "env -S" argument, "# scriptman:" directive,
+// or versionOverride-built value of the form "name>=1.2.3" or the
+// comma-separated range "name>=3.11,<3.12", capturing the base name and the
+// full constraint.
+var inlineConstraintRe = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_+-]*)((?:>=|<=|==|>|<)[0-9][0-9.,<>=]*)$`)
+
+// scriptmanDirectiveRe matches a trailing directive comment such as
+// "# scriptman:python>=3.10,<4" anywhere in a script.
+var scriptmanDirectiveRe = regexp.MustCompile(`(?m)^[ \t]*#[ \t]*scriptman:[ \t]*([A-Za-z_][A-Za-z0-9_+-]*)((?:>=|<=|==|>|<)[0-9][0-9.,<>=]*)[ \t]*$`)
+
+// finalizeShebangInfo recognizes a version constraint on an already-parsed
+// shebang, in ascending order of precedence: a versioned interpreter token
+// or "-<version>" argument (see splitVersionedInterpreter, e.g. "python3.11"
+// or "env python3 -3.11"), an inline "name>=version" token (from an "env -S"
+// form), or - taking precedence over both - a
+// "# scriptman:name>=version[,<op>version...]" directive comment anywhere
+// in the script.
+func finalizeShebangInfo(info *shebangInfo, content []byte) *shebangInfo {
+	if base, constraint, consumedArg, ok := splitVersionedInterpreter(info.interpreter, info.arguments); ok {
+		info.interpreter = base
+		info.versionConstraint = constraint
+		if consumedArg >= 0 {
+			info.arguments = append(append([]string{}, info.arguments[:consumedArg]...), info.arguments[consumedArg+1:]...)
+		}
+	}
+
+	if m := inlineConstraintRe.FindStringSubmatch(info.interpreter); m != nil {
+		info.interpreter = m[1]
+		info.versionConstraint = m[2]
+	}
+
+	if m := scriptmanDirectiveRe.FindSubmatch(content); m != nil {
+		info.interpreter = string(m[1])
+		info.versionConstraint = string(m[2])
+	}
+
+	return info
+}
+
+// applyVersionConstraint eagerly resolves a shebang's version constraint (if
+// any) so that an unsatisfiable constraint surfaces as a clear
+// DecisionResult.Error identifying the missing interpreter, rather than
+// failing later and silently inside resolveChoice. Every remaining
+// shebang-sourced choice is annotated with the constraint for Detect to
+// resolve against.
+func applyVersionConstraint(result DecisionResult, shebang *shebangInfo) DecisionResult {
+	if shebang.versionConstraint == "" || result.Error != nil {
+		return result
+	}
+
+	if _, err := ResolveFamilyInterpreter(shebang.interpreter, shebang.versionConstraint); err != nil {
+		return DecisionResult{Error: fmt.Errorf("shebang requires %s%s: %w", shebang.interpreter, shebang.versionConstraint, err)}
+	}
+
+	for i := range result.Choices {
+		if result.Choices[i].Source == "shebang" {
+			result.Choices[i].VersionConstraint = shebang.versionConstraint
+		}
+	}
+
+	return result
+}
+
+// versionClause is a single comparison within a constraint, e.g. the ">=3.10"
+// in ">=3.10,<4".
+type versionClause struct {
+	op      string
+	version []int
+}
+
+var clauseRe = regexp.MustCompile(`^(>=|<=|==|>|<)(\d+(?:\.\d+)*)$`)
+
+// parseConstraintClauses parses a comma-separated constraint such as
+// ">=3.10,<4" into its individual clauses, all of which must hold.
+func parseConstraintClauses(constraint string) ([]versionClause, error) {
+	var clauses []versionClause
+	for _, raw := range strings.Split(constraint, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		m := clauseRe.FindStringSubmatch(raw)
+		if m == nil {
+			return nil, fmt.Errorf("unrecognized constraint clause %q", raw)
+		}
+		version, err := parseVersionParts(m[2])
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, versionClause{op: m[1], version: version})
+	}
+	if len(clauses) == 0 {
+		return nil, fmt.Errorf("empty version constraint")
+	}
+	return clauses, nil
+}
+
+func satisfiesClauses(v []int, clauses []versionClause) bool {
+	for _, c := range clauses {
+		cmp := compareVersionParts(v, c.version)
+		switch c.op {
+		case ">=":
+			if cmp < 0 {
+				return false
+			}
+		case "<=":
+			if cmp > 0 {
+				return false
+			}
+		case "==":
+			if cmp != 0 {
+				return false
+			}
+		case ">":
+			if cmp <= 0 {
+				return false
+			}
+		case "<":
+			if cmp >= 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func parseVersionParts(s string) ([]int, error) {
+	fields := strings.Split(s, ".")
+	parts := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version segment %q in %q", f, s)
+		}
+		parts[i] = n
+	}
+	return parts, nil
+}
+
+// compareVersionParts compares two dotted-integer versions, treating
+// missing trailing segments as 0 (so "3.10" == "3.10.0").
+func compareVersionParts(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var av, bv int
+		if i < len(a) {
+			av = a[i]
+		}
+		if i < len(b) {
+			bv = b[i]
+		}
+		if av != bv {
+			return av - bv
+		}
+	}
+	return 0
+}
+
+// interpreterCacheEntry records a previously-probed interpreter's version,
+// plus the binary's mtime at probe time so a replaced binary is re-probed.
+type interpreterCacheEntry struct {
+	Version string `json:"version"`
+	ModTime int64  `json:"mod_time"`
+}
+
+// interpreterCache is the on-disk cache of probed interpreter versions,
+// keyed by absolute executable path.
+type interpreterCache struct {
+	Entries map[string]interpreterCacheEntry `json:"entries"`
+}
+
+func loadInterpreterCache(path string) (*interpreterCache, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &interpreterCache{Entries: make(map[string]interpreterCacheEntry)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read interpreter cache: %w", err)
+	}
+
+	var cache interpreterCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to parse interpreter cache: %w", err)
+	}
+	if cache.Entries == nil {
+		cache.Entries = make(map[string]interpreterCacheEntry)
+	}
+
+	return &cache, nil
+}
+
+func (c *interpreterCache) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create interpreter cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal interpreter cache: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write interpreter cache: %w", err)
+	}
+
+	return nil
+}
+
+func cachedVersion(cache *interpreterCache, candidate string) (string, bool) {
+	entry, ok := cache.Entries[candidate]
+	if !ok {
+		return "", false
+	}
+	info, err := os.Stat(candidate)
+	if err != nil || info.ModTime().Unix() != entry.ModTime {
+		return "", false
+	}
+	return entry.Version, true
+}
+
+func cacheVersion(cache *interpreterCache, candidate, version string) {
+	modTime := int64(0)
+	if info, err := os.Stat(candidate); err == nil {
+		modTime = info.ModTime().Unix()
+	}
+	cache.Entries[candidate] = interpreterCacheEntry{Version: version, ModTime: modTime}
+}
+
+// findCandidates returns every executable on PATH named base, or base
+// followed by a version suffix (e.g. base "python" matches "python",
+// "python3", "python3.11", and base "python3" matches "python3.11"),
+// deduplicated by path.
+func findCandidates(base string) []string {
+	var candidates []string
+	seen := make(map[string]bool)
+
+	addIfExecutable := func(path string) {
+		if seen[path] {
+			return
+		}
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			seen[path] = true
+			candidates = append(candidates, path)
+		}
+	}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		addIfExecutable(filepath.Join(dir, base))
+		matches, _ := filepath.Glob(filepath.Join(dir, base+"[0-9]*"))
+		dotted, _ := filepath.Glob(filepath.Join(dir, base+".[0-9]*"))
+		for _, m := range append(matches, dotted...) {
+			addIfExecutable(m)
+		}
+	}
+
+	return candidates
+}
+
+var versionOutputRe = regexp.MustCompile(`\d+(?:\.\d+){1,3}`)
+
+// probeVersion runs "candidate --version" and extracts the first
+// dotted-integer version number from its output.
+func probeVersion(candidate string) (string, error) {
+	out, err := exec.Command(candidate, "--version").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s --version: %w", candidate, err)
+	}
+
+	match := versionOutputRe.Find(out)
+	if match == nil {
+		return "", fmt.Errorf("could not parse a version number from %s --version output", candidate)
+	}
+
+	return string(match), nil
+}
+
+// ResolveVersionedInterpreter finds the newest interpreter on PATH named
+// base (or base followed by a version suffix, e.g. "python3.11") whose
+// "--version" output satisfies constraint (a comma-separated list of
+// semver-style clauses such as ">=3.10,<4"). Probed versions are cached in
+// ~/.cache/scriptman/interpreters.json, keyed by executable path and mtime,
+// so repeat invocations don't re-spawn every candidate.
+func ResolveVersionedInterpreter(base, constraint string) (string, error) {
+	clauses, err := parseConstraintClauses(constraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid version constraint %q for %s: %w", constraint, base, err)
+	}
+
+	candidates := findCandidates(base)
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no interpreter named %s (or %s<version>) found on PATH to satisfy %s%s", base, base, base, constraint)
+	}
+
+	cachePath := config.GetDefaultInterpreterCachePath()
+	cache, err := loadInterpreterCache(cachePath)
+	if err != nil {
+		cache = &interpreterCache{Entries: make(map[string]interpreterCacheEntry)}
+	}
+
+	type candidateVersion struct {
+		path    string
+		version []int
+	}
+	var satisfying []candidateVersion
+	dirty := false
+
+	for _, candidate := range candidates {
+		versionStr, ok := cachedVersion(cache, candidate)
+		if !ok {
+			probed, err := probeVersion(candidate)
+			if err != nil {
+				continue
+			}
+			versionStr = probed
+			cacheVersion(cache, candidate, versionStr)
+			dirty = true
+		}
+
+		v, err := parseVersionParts(versionStr)
+		if err != nil {
+			continue
+		}
+		if satisfiesClauses(v, clauses) {
+			satisfying = append(satisfying, candidateVersion{path: candidate, version: v})
+		}
+	}
+
+	if dirty {
+		_ = cache.save(cachePath) // best-effort: a stale cache just costs a re-probe next time
+	}
+
+	if len(satisfying) == 0 {
+		return "", fmt.Errorf("no interpreter satisfies %s%s (checked: %s)", base, constraint, strings.Join(candidates, ", "))
+	}
+
+	sort.Slice(satisfying, func(i, j int) bool {
+		return compareVersionParts(satisfying[i].version, satisfying[j].version) > 0
+	})
+
+	return satisfying[0].path, nil
+}
@@ -0,0 +1,209 @@
+package interpreter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeExecutable creates an empty executable file at path, for tests that
+// exercise PATH-scanning or mtime-based cache invalidation without actually
+// running a real interpreter binary.
+func writeExecutable(t *testing.T, path string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("WriteFile(%s): %v", path, err)
+	}
+}
+
+// touchLater advances path's mtime into the future, simulating a binary
+// having been replaced since it was last cached.
+func touchLater(t *testing.T, path string) {
+	t.Helper()
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("Chtimes(%s): %v", path, err)
+	}
+}
+
+func TestParseVersionParts(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []int
+		wantErr bool
+	}{
+		{in: "3.11.4", want: []int{3, 11, 4}},
+		{in: "2", want: []int{2}},
+		{in: "3.x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseVersionParts(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q", tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareVersionParts(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []int
+		want int
+	}{
+		{name: "Equal", a: []int{3, 11}, b: []int{3, 11}, want: 0},
+		{name: "TrailingZeroImplied", a: []int{3, 10}, b: []int{3, 10, 0}, want: 0},
+		{name: "Greater", a: []int{3, 12}, b: []int{3, 11}, want: 1},
+		{name: "Less", a: []int{3, 9}, b: []int{3, 11}, want: -2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compareVersionParts(tt.a, tt.b)
+			if (got == 0) != (tt.want == 0) || (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+				t.Errorf("compareVersionParts(%v, %v) = %d, want same sign as %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConstraintClauses(t *testing.T) {
+	clauses, err := parseConstraintClauses(">=3.10,<4")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clauses) != 2 {
+		t.Fatalf("expected 2 clauses, got %d", len(clauses))
+	}
+	if clauses[0].op != ">=" || clauses[1].op != "<" {
+		t.Errorf("got ops %q, %q, want >=, <", clauses[0].op, clauses[1].op)
+	}
+
+	if _, err := parseConstraintClauses(""); err == nil {
+		t.Fatalf("expected error for empty constraint")
+	}
+	if _, err := parseConstraintClauses("not-a-clause"); err == nil {
+		t.Fatalf("expected error for unrecognized clause")
+	}
+}
+
+func TestSatisfiesClauses(t *testing.T) {
+	clauses, err := parseConstraintClauses(">=3.10,<4")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		v    []int
+		want bool
+	}{
+		{name: "WithinRange", v: []int{3, 11, 4}, want: true},
+		{name: "BelowLowerBound", v: []int{3, 9}, want: false},
+		{name: "AtUpperBound", v: []int{4, 0}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := satisfiesClauses(tt.v, clauses); got != tt.want {
+				t.Errorf("satisfiesClauses(%v) = %v, want %v", tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionOutputRe(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{output: "Python 3.11.4", want: "3.11.4"},
+		{output: "ruby 3.2.2 (2023-03-30 revision e51014f9c0) [x86_64-linux]", want: "3.2.2"},
+		{output: "v18.16.0", want: "18.16.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			got := versionOutputRe.FindString(tt.output)
+			if got != tt.want {
+				t.Errorf("versionOutputRe.FindString(%q) = %q, want %q", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpreterCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "interpreters.json")
+
+	cache, err := loadInterpreterCache(path)
+	if err != nil {
+		t.Fatalf("loadInterpreterCache(nonexistent) error: %v", err)
+	}
+	if len(cache.Entries) != 0 {
+		t.Fatalf("expected empty cache, got %d entries", len(cache.Entries))
+	}
+
+	cacheVersion(cache, "/usr/bin/python3", "3.11.4")
+	if err := cache.save(path); err != nil {
+		t.Fatalf("save error: %v", err)
+	}
+
+	reloaded, err := loadInterpreterCache(path)
+	if err != nil {
+		t.Fatalf("loadInterpreterCache(existing) error: %v", err)
+	}
+	entry, ok := reloaded.Entries["/usr/bin/python3"]
+	if !ok {
+		t.Fatalf("expected cache entry for /usr/bin/python3")
+	}
+	if entry.Version != "3.11.4" {
+		t.Errorf("entry.Version = %q, want 3.11.4", entry.Version)
+	}
+}
+
+func TestCachedVersionInvalidatesOnModTimeChange(t *testing.T) {
+	dir := t.TempDir()
+	candidate := filepath.Join(dir, "python3")
+	writeExecutable(t, candidate)
+
+	cache := &interpreterCache{Entries: make(map[string]interpreterCacheEntry)}
+	cacheVersion(cache, candidate, "3.11.4")
+
+	if _, ok := cachedVersion(cache, candidate); !ok {
+		t.Fatalf("expected cached version to be valid immediately after caching")
+	}
+
+	// Replacing the binary changes its mtime, which should invalidate the
+	// cache entry rather than return a stale version for the new binary.
+	touchLater(t, candidate)
+	if _, ok := cachedVersion(cache, candidate); ok {
+		t.Errorf("expected cached version to be invalidated after the binary's mtime changed")
+	}
+}
+
+func TestFindCandidates(t *testing.T) {
+	dir := t.TempDir()
+	writeExecutable(t, filepath.Join(dir, "python3"))
+	writeExecutable(t, filepath.Join(dir, "python3.11"))
+	writeExecutable(t, filepath.Join(dir, "not-python"))
+
+	t.Setenv("PATH", dir)
+
+	candidates := findCandidates("python3")
+	if len(candidates) != 2 {
+		t.Fatalf("expected 2 candidates, got %d: %v", len(candidates), candidates)
+	}
+}
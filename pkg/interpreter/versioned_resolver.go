@@ -0,0 +1,129 @@
+package interpreter
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// familyEnvOverride maps an interpreter family to the environment variable
+// that pins it outright (e.g. SCRIPTMAN_PYTHON=/opt/python3.12/bin/python3),
+// bypassing the PATH search entirely - mirroring how pyenv/rbenv shims
+// respect an explicit override.
+var familyEnvOverride = map[string]string{
+	"python":  "SCRIPTMAN_PYTHON",
+	"python2": "SCRIPTMAN_PYTHON",
+	"python3": "SCRIPTMAN_PYTHON",
+	"ruby":    "SCRIPTMAN_RUBY",
+	"ruby2":   "SCRIPTMAN_RUBY",
+	"ruby3":   "SCRIPTMAN_RUBY",
+	"node":    "SCRIPTMAN_NODE",
+	"nodejs":  "SCRIPTMAN_NODE",
+}
+
+// dottedVersionRe splits an interpreter token like "python3.11" or
+// "ruby2.7" into a base name and a dotted version suffix.
+var dottedVersionRe = regexp.MustCompile(`^([A-Za-z_]+?)(\d+\.\d+(?:\.\d+)*)$`)
+
+// atVersionRe splits a "name@version" token such as "node@18".
+var atVersionRe = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_+-]*)@(\d+(?:\.\d+)*)$`)
+
+// majorVersionFamilyRe recognizes the handful of bare "family+major-digit"
+// names - python2, python3, ruby2, ruby3 - that, Python-Launcher style,
+// name a major version to search for rather than a literal binary.
+var majorVersionFamilyRe = regexp.MustCompile(`^(python|ruby)([23])$`)
+
+// shebangArgVersionRe matches a "-3.11"-style version flag passed as a
+// shebang argument, e.g. "#!/usr/bin/env python3 -3.11" (the Python
+// Launcher's own "-V:3.11" convention, without the "V:").
+var shebangArgVersionRe = regexp.MustCompile(`^-(\d+(?:\.\d+)*)$`)
+
+// VersionRangeConstraint turns a requested version like "3.11" or "2" into
+// a half-open range constraint ">=3.11,<3.12" / ">=2,<3" that matches any
+// patch release, rather than an exact match that would reject "3.11.2"
+// against a request for "3.11". A fully-qualified major.minor.patch version
+// is matched exactly, since there's no sensible upper bound to infer.
+func VersionRangeConstraint(version string) string {
+	parts := strings.Split(version, ".")
+	if len(parts) >= 3 {
+		return "==" + version
+	}
+
+	n, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return "==" + version
+	}
+
+	upper := append(append([]string{}, parts[:len(parts)-1]...), strconv.Itoa(n+1))
+	return ">=" + version + ",<" + strings.Join(upper, ".")
+}
+
+// splitVersionedInterpreter extracts a base interpreter name and a version
+// constraint from a shebang's interpreter token and its arguments, trying
+// (in order) a "-<version>" shebang argument, a "name@version" token, a
+// dotted-version token, and a bare major-version family name. consumedArg
+// is the index into arguments that supplied the constraint, or -1 if it
+// came from the interpreter token itself.
+func splitVersionedInterpreter(interp string, arguments []string) (base, constraint string, consumedArg int, ok bool) {
+	for i, arg := range arguments {
+		if m := shebangArgVersionRe.FindStringSubmatch(arg); m != nil {
+			return interp, VersionRangeConstraint(m[1]), i, true
+		}
+	}
+
+	if m := atVersionRe.FindStringSubmatch(interp); m != nil {
+		return m[1], VersionRangeConstraint(m[2]), -1, true
+	}
+
+	if m := dottedVersionRe.FindStringSubmatch(interp); m != nil {
+		return m[1], VersionRangeConstraint(m[2]), -1, true
+	}
+
+	if m := majorVersionFamilyRe.FindStringSubmatch(interp); m != nil {
+		return m[1], VersionRangeConstraint(m[2]), -1, true
+	}
+
+	return interp, "", -1, false
+}
+
+// resolveNamedInterpreter resolves an interpreter token that may itself
+// carry an inline version constraint (anything inlineConstraintRe matches,
+// e.g. "python>=3.11,<3.12", as produced by versionOverride-style --python/
+// --ruby/--node flags), falling back to a literal PATH lookup otherwise.
+func resolveNamedInterpreter(name string) (string, error) {
+	if m := inlineConstraintRe.FindStringSubmatch(name); m != nil {
+		return ResolveFamilyInterpreter(m[1], m[2])
+	}
+	return resolveInterpreter(name)
+}
+
+// ResolveFamilyInterpreter resolves base+constraint the same way
+// ResolveVersionedInterpreter does, except it first honors a family
+// environment override (SCRIPTMAN_PYTHON, SCRIPTMAN_RUBY, SCRIPTMAN_NODE),
+// which - like an explicit --interpreter - is trusted outright rather than
+// re-verified against constraint.
+func ResolveFamilyInterpreter(base, constraint string) (string, error) {
+	if envVar, ok := familyEnvOverride[base]; ok {
+		if override := os.Getenv(envVar); override != "" {
+			return override, nil
+		}
+	}
+
+	path, err := ResolveVersionedInterpreter(base, constraint)
+	if err != nil {
+		return "", fmt.Errorf("%w (set %s to override)", err, envVarHint(base))
+	}
+	return path, nil
+}
+
+// envVarHint names the environment variable that would override base's
+// resolution, for use in error messages; it falls back to a generic
+// suggestion for families with no dedicated override.
+func envVarHint(base string) string {
+	if envVar, ok := familyEnvOverride[base]; ok {
+		return envVar
+	}
+	return "SCRIPTMAN_" + strings.ToUpper(base)
+}
@@ -0,0 +1,59 @@
+package interpreter
+
+import "testing"
+
+func TestVersionRangeConstraint(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{name: "MajorMinor", version: "3.11", want: ">=3.11,<3.12"},
+		{name: "MajorOnly", version: "2", want: ">=2,<3"},
+		{name: "FullyQualifiedPatch", version: "3.11.4", want: "==3.11.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VersionRangeConstraint(tt.version); got != tt.want {
+				t.Errorf("VersionRangeConstraint(%q) = %q, want %q", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionRangeConstraintMatchesPatchReleases(t *testing.T) {
+	constraint := VersionRangeConstraint("3.11")
+	clauses, err := parseConstraintClauses(constraint)
+	if err != nil {
+		t.Fatalf("parseConstraintClauses(%q) error: %v", constraint, err)
+	}
+
+	// A real "python3.11 --version" reports a patch release, e.g. 3.11.4;
+	// an exact "==3.11" constraint would reject it (this was the chunk2-3
+	// bug: versionOverride used to build that literal "==" constraint).
+	if !satisfiesClauses([]int{3, 11, 4}, clauses) {
+		t.Errorf("constraint %q should be satisfied by 3.11.4", constraint)
+	}
+	if satisfiesClauses([]int{3, 12, 0}, clauses) {
+		t.Errorf("constraint %q should not be satisfied by 3.12.0", constraint)
+	}
+	if satisfiesClauses([]int{3, 10, 9}, clauses) {
+		t.Errorf("constraint %q should not be satisfied by 3.10.9", constraint)
+	}
+}
+
+func TestInlineConstraintReSupportsRanges(t *testing.T) {
+	m := inlineConstraintRe.FindStringSubmatch("python>=3.11,<3.12")
+	if m == nil {
+		t.Fatalf("expected inlineConstraintRe to match a comma-separated range")
+	}
+	if m[1] != "python" || m[2] != ">=3.11,<3.12" {
+		t.Errorf("got name=%q constraint=%q, want name=python constraint=>=3.11,<3.12", m[1], m[2])
+	}
+}
+
+func TestResolveNamedInterpreterRejectsUnsatisfiableRange(t *testing.T) {
+	if _, err := resolveNamedInterpreter("python>=999.0,<999.1"); err == nil {
+		t.Fatalf("expected error resolving an unsatisfiable version range")
+	}
+}
@@ -0,0 +1,178 @@
+// Package linter runs an external static-analysis tool appropriate to a
+// script's interpreter family before it is wrapped and installed, the way
+// shellcheck is commonly run over shell scripts before trusting them.
+// Built-in linters shell out to shellcheck, pyflakes, ruby -c, perl -c,
+// node --check, php -l, and luac -p; RegisterLinter adds more.
+package linter
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Lint modes controlling what a failed Check means for the install.
+const (
+	// ModeOff skips linting entirely.
+	ModeOff = "off"
+	// ModeWarn runs the linter and prints any diagnostics, but never aborts.
+	ModeWarn = "warn"
+	// ModeStrict runs the linter and aborts the install if Check returns an
+	// error, or any diagnostic at severity "error".
+	ModeStrict = "strict"
+)
+
+// Diagnostic is a single finding reported by a Linter.
+type Diagnostic struct {
+	Line     int    // 1-based; 0 if the tool didn't report a line
+	Severity string // "error", "warning", or "note"
+	Message  string
+}
+
+// Linter checks scriptPath's content for problems, returning one Diagnostic
+// per finding. The returned error is reserved for the linter itself failing
+// to run (tool missing, content unparseable); a script with findings but no
+// such error still returns a nil error alongside its Diagnostics.
+type Linter interface {
+	Check(scriptPath string, content []byte) ([]Diagnostic, error)
+}
+
+// LinterFunc adapts a plain function to the Linter interface.
+type LinterFunc func(scriptPath string, content []byte) ([]Diagnostic, error)
+
+func (f LinterFunc) Check(scriptPath string, content []byte) ([]Diagnostic, error) {
+	return f(scriptPath, content)
+}
+
+// linters maps an interpreter family (see interpreter.Family) to the
+// registered Linter for it. A family with no entry is simply not linted.
+var linters = map[string]Linter{
+	"shell":      LinterFunc(toolLinter("shellcheck", shellcheckArgs, shellcheckDiagnosticRe)),
+	"python":     LinterFunc(pythonLint),
+	"ruby":       LinterFunc(toolLinter("ruby", rubyCheckArgs, rubyDiagnosticRe)),
+	"perl":       LinterFunc(toolLinter("perl", perlCheckArgs, perlDiagnosticRe)),
+	"javascript": LinterFunc(toolLinter("node", nodeCheckArgs, nodeDiagnosticRe)),
+	"php":        LinterFunc(toolLinter("php", phpCheckArgs, phpDiagnosticRe)),
+	"lua":        LinterFunc(toolLinter("luac", luacCheckArgs, luacDiagnosticRe)),
+}
+
+// RegisterLinter adds (or replaces) the Linter used for family, for callers
+// that want a custom tool (e.g. ruff instead of pyflakes) or support for a
+// family scriptman doesn't lint out of the box.
+func RegisterLinter(family string, l Linter) {
+	linters[family] = l
+}
+
+// Lookup returns the registered Linter for family, if any.
+func Lookup(family string) (Linter, bool) {
+	l, ok := linters[family]
+	return l, ok
+}
+
+func shellcheckArgs(scriptPath string) []string { return []string{"-f", "gcc", scriptPath} }
+func rubyCheckArgs(scriptPath string) []string  { return []string{"-c", scriptPath} }
+func perlCheckArgs(scriptPath string) []string  { return []string{"-c", scriptPath} }
+func nodeCheckArgs(scriptPath string) []string  { return []string{"--check", scriptPath} }
+func phpCheckArgs(scriptPath string) []string   { return []string{"-l", scriptPath} }
+func luacCheckArgs(scriptPath string) []string  { return []string{"-p", scriptPath} }
+
+// shellcheckDiagnosticRe matches shellcheck's "-f gcc" output:
+// "script.sh:12:3: warning: message [SC2086]".
+var shellcheckDiagnosticRe = regexp.MustCompile(`^[^:]+:(\d+):\d+:\s*(error|warning|note):\s*(.*)$`)
+
+// rubyDiagnosticRe matches "ruby -c" syntax-error output:
+// "script.rb:5: syntax error, unexpected ...".
+var rubyDiagnosticRe = regexp.MustCompile(`^[^:]+:(\d+):\s*(.*)$`)
+
+// perlDiagnosticRe matches "perl -c" output: "... at script.pl line 5.".
+var perlDiagnosticRe = regexp.MustCompile(`line (\d+)`)
+
+// nodeDiagnosticRe matches "node --check" output: "script.js:5".
+var nodeDiagnosticRe = regexp.MustCompile(`^[^:]+:(\d+)`)
+
+// phpDiagnosticRe matches "php -l" output: "... in script.php on line 5".
+var phpDiagnosticRe = regexp.MustCompile(`on line (\d+)`)
+
+// luacDiagnosticRe matches "luac -p" output: "luac: script.lua:5: message".
+var luacDiagnosticRe = regexp.MustCompile(`^luac:[^:]+:(\d+):\s*(.*)$`)
+
+// toolLinter builds a Linter that runs an external tool over scriptPath and
+// parses its combined stdout+stderr with lineRe, one Diagnostic per matched
+// line, falling back to a single diagnostic carrying the raw output if
+// nothing matches but the tool still exited non-zero.
+func toolLinter(name string, argsFor func(string) []string, lineRe *regexp.Regexp) func(string, []byte) ([]Diagnostic, error) {
+	return func(scriptPath string, _ []byte) ([]Diagnostic, error) {
+		cmd := exec.Command(name, argsFor(scriptPath)...)
+		out, runErr := cmd.CombinedOutput()
+		if runErr != nil {
+			if _, ok := runErr.(*exec.Error); ok {
+				return nil, fmt.Errorf("%s is not installed: %w", name, runErr)
+			}
+		}
+
+		var diags []Diagnostic
+		scanner := bufio.NewScanner(strings.NewReader(string(out)))
+		for scanner.Scan() {
+			line := scanner.Text()
+			m := lineRe.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			diags = append(diags, Diagnostic{Line: diagnosticLine(m), Severity: diagnosticSeverity(m), Message: strings.TrimSpace(line)})
+		}
+
+		if len(diags) == 0 && runErr != nil {
+			diags = append(diags, Diagnostic{Severity: "error", Message: strings.TrimSpace(string(out))})
+		}
+
+		return diags, nil
+	}
+}
+
+// diagnosticLine pulls a 1-based line number out of a toolLinter regex
+// match, if the pattern captured one.
+func diagnosticLine(m []string) int {
+	for _, g := range m[1:] {
+		n := 0
+		for _, r := range g {
+			if r < '0' || r > '9' {
+				n = -1
+				break
+			}
+			n = n*10 + int(r-'0')
+		}
+		if n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// diagnosticSeverity pulls "error"/"warning"/"note" out of a toolLinter
+// regex match, defaulting to "error" when the tool doesn't label severity
+// (ruby -c, perl -c, node --check, php -l, luac -p all report only failures).
+func diagnosticSeverity(m []string) string {
+	for _, g := range m[1:] {
+		switch g {
+		case "error", "warning", "note":
+			return g
+		}
+	}
+	return "error"
+}
+
+// pyflakesDiagnosticRe matches pyflakes output: "script.py:5: message".
+var pyflakesDiagnosticRe = regexp.MustCompile(`^[^:]+:(\d+):\s*(.*)$`)
+
+// pythonLint runs pyflakes, falling back to ruff if pyflakes isn't
+// installed (both are common in the wild; pyflakes is the narrower,
+// longer-established tool so it's tried first).
+func pythonLint(scriptPath string, content []byte) ([]Diagnostic, error) {
+	diags, err := toolLinter("pyflakes", func(p string) []string { return []string{p} }, pyflakesDiagnosticRe)(scriptPath, content)
+	if err == nil {
+		return diags, nil
+	}
+	return toolLinter("ruff", func(p string) []string { return []string{"check", p} }, pyflakesDiagnosticRe)(scriptPath, content)
+}
@@ -0,0 +1,82 @@
+package linter
+
+import "testing"
+
+func TestDiagnosticLine(t *testing.T) {
+	tests := []struct {
+		name string
+		re   string
+		line string
+		want int
+	}{
+		{name: "Shellcheck", re: shellcheckDiagnosticRe.String(), line: "script.sh:12:3: warning: message [SC2086]", want: 12},
+		{name: "Ruby", re: rubyDiagnosticRe.String(), line: "script.rb:5: syntax error, unexpected end", want: 5},
+		{name: "Node", re: nodeDiagnosticRe.String(), line: "script.js:5", want: 5},
+		{name: "NoMatch", re: nodeDiagnosticRe.String(), line: "no line number here", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m []string
+			switch tt.re {
+			case shellcheckDiagnosticRe.String():
+				m = shellcheckDiagnosticRe.FindStringSubmatch(tt.line)
+			case rubyDiagnosticRe.String():
+				m = rubyDiagnosticRe.FindStringSubmatch(tt.line)
+			case nodeDiagnosticRe.String():
+				m = nodeDiagnosticRe.FindStringSubmatch(tt.line)
+			}
+			if m == nil {
+				if tt.want != 0 {
+					t.Fatalf("no match for %q, want line %d", tt.line, tt.want)
+				}
+				return
+			}
+			if got := diagnosticLine(m); got != tt.want {
+				t.Errorf("diagnosticLine(%v) = %d, want %d", m, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiagnosticSeverity(t *testing.T) {
+	m := shellcheckDiagnosticRe.FindStringSubmatch("script.sh:12:3: warning: message [SC2086]")
+	if m == nil {
+		t.Fatalf("expected match")
+	}
+	if got := diagnosticSeverity(m); got != "warning" {
+		t.Errorf("diagnosticSeverity = %q, want warning", got)
+	}
+
+	m = rubyDiagnosticRe.FindStringSubmatch("script.rb:5: syntax error, unexpected end")
+	if m == nil {
+		t.Fatalf("expected match")
+	}
+	if got := diagnosticSeverity(m); got != "error" {
+		t.Errorf("diagnosticSeverity default = %q, want error", got)
+	}
+}
+
+func TestLookupAndRegisterLinter(t *testing.T) {
+	if _, ok := Lookup("nonexistent-family"); ok {
+		t.Fatalf("Lookup(nonexistent-family) = ok, want not found")
+	}
+
+	called := false
+	RegisterLinter("test-family", LinterFunc(func(scriptPath string, content []byte) ([]Diagnostic, error) {
+		called = true
+		return nil, nil
+	}))
+	defer delete(linters, "test-family")
+
+	l, ok := Lookup("test-family")
+	if !ok {
+		t.Fatalf("Lookup(test-family) = not found after RegisterLinter")
+	}
+	if _, err := l.Check("script.sh", nil); err != nil {
+		t.Fatalf("Check error: %v", err)
+	}
+	if !called {
+		t.Errorf("registered linter was not invoked")
+	}
+}
@@ -0,0 +1,113 @@
+// Package manifest parses the declarative script manifest consumed by
+// `scriptman install --from-file` and `scriptman sync`.
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sfkleach/scriptman/pkg/source"
+	"gopkg.in/yaml.v3"
+)
+
+// Entry describes a single script to install, as declared in a manifest file.
+type Entry struct {
+	Repo        string `yaml:"repo"`
+	Path        string `yaml:"path"`
+	Name        string `yaml:"name,omitempty"`
+	Interpreter string `yaml:"interpreter,omitempty"`
+	Tag         string `yaml:"tag,omitempty"`
+	Into        string `yaml:"into,omitempty"`
+}
+
+// Manifest is a declarative list of scripts that `scriptman sync` converges
+// the local registry towards.
+type Manifest struct {
+	Scripts []Entry `yaml:"scripts"`
+}
+
+// Load reads and validates a manifest file from disk.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Validate rejects duplicate names, the reserved "scriptman" name, and
+// malformed repo strings.
+func (m *Manifest) Validate() error {
+	seen := make(map[string]bool, len(m.Scripts))
+	for i, entry := range m.Scripts {
+		name := entry.Name
+		if name == "" {
+			name = entry.defaultName()
+		}
+
+		if name == "" {
+			return fmt.Errorf("manifest entry %d: could not determine a name (set 'path' or 'name')", i)
+		}
+		if name == "scriptman" {
+			return fmt.Errorf("manifest entry %d: 'scriptman' is reserved for the management CLI", i)
+		}
+		if seen[name] {
+			return fmt.Errorf("manifest entry %d: duplicate name '%s'", i, name)
+		}
+		seen[name] = true
+
+		if entry.Path == "" {
+			return fmt.Errorf("manifest entry %d ('%s'): missing path", i, name)
+		}
+		if !looksLikeRepo(entry.Repo) {
+			return fmt.Errorf("manifest entry %d ('%s'): malformed repo '%s' (expected owner/repo, a GitHub/GitLab/Gitea URL, a raw https:// URL, or a file:// path)", i, name, entry.Repo)
+		}
+	}
+	return nil
+}
+
+// EntryName returns the wrapper name for an entry, defaulting to the script
+// filename without extension when Name is not set.
+func (e Entry) EntryName() string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return e.defaultName()
+}
+
+// defaultName derives a wrapper name from the entry's path.
+func (e Entry) defaultName() string {
+	base := e.Path
+	if idx := strings.LastIndexByte(base, '/'); idx >= 0 {
+		base = base[idx+1:]
+	}
+	if idx := strings.LastIndexByte(base, '.'); idx > 0 {
+		base = base[:idx]
+	}
+	return base
+}
+
+// looksLikeRepo reports whether a repo string is anything source.Detect can
+// route to a backend: "owner/repo", a GitHub/GitLab/self-hosted Gitea
+// host-prefixed form, a raw https:// URL, or a file:// path. Deferring to
+// source.Detect (rather than a separate, narrower owner/repo check) keeps
+// this validation in sync with whatever backends pkg/source actually
+// supports.
+func looksLikeRepo(repo string) bool {
+	if repo == "" {
+		return false
+	}
+	_, _, err := source.Detect(repo)
+	return err == nil
+}
@@ -0,0 +1,36 @@
+package manifest
+
+import "testing"
+
+// TestValidate_RepoForms ensures Validate accepts every repo form
+// source.Detect can route (not just bare "owner/repo"), so a manifest entry
+// targeting GitLab, Gitea, a raw URL, or a local file isn't rejected before
+// `sync`/`install --from-file` ever reaches source.Detect.
+func TestValidate_RepoForms(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    string
+		wantErr bool
+	}{
+		{name: "PlainOwnerRepo", repo: "owner/repo"},
+		{name: "GitHubURL", repo: "https://github.com/owner/repo"},
+		{name: "GitLabDotCom", repo: "gitlab.com/owner/repo"},
+		{name: "SelfHostedGitea", repo: "gitea.example.com/owner/repo"},
+		{name: "HTTPSRawURL", repo: "https://raw.example.com/foo.sh"},
+		{name: "FileURL", repo: "file:///srv/mirror"},
+		{name: "Empty", repo: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{Scripts: []Entry{{Repo: tt.repo, Path: "bin/tool.sh"}}}
+			err := m.Validate()
+			if tt.wantErr && err == nil {
+				t.Fatalf("Validate() with repo %q: expected error, got nil", tt.repo)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Validate() with repo %q: unexpected error: %v", tt.repo, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package platform provides small, pure helpers that let the rest of
+// scriptman reason about how a script must be invoked on a given OS or
+// under a given interpreter, without scattering GOOS checks and
+// cmd.exe/PowerShell special cases through pkg/interpreter and pkg/install.
+package platform
+
+import "strings"
+
+// windowsShellInterpreters are interpreter names that are native Windows
+// shells: they have no notion of a "#!" line and must be invoked directly,
+// with the script handed to them as an argument rather than execed via a
+// kernel shebang.
+var windowsShellInterpreters = map[string]bool{
+	"cmd":            true,
+	"cmd.exe":        true,
+	"powershell":     true,
+	"powershell.exe": true,
+	"pwsh":           true,
+	"pwsh.exe":       true,
+}
+
+// baseName strips any path prefix (POSIX or Windows-style) and lower-cases
+// the result, so callers can pass a bare interpreter name or a resolved
+// absolute path interchangeably.
+func baseName(interpreter string) string {
+	name := interpreter
+	if idx := strings.LastIndexAny(name, `/\`); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return strings.ToLower(name)
+}
+
+// IsWindowsShellInterpreter reports whether interpreter is cmd or
+// PowerShell, under any of their common name spellings.
+func IsWindowsShellInterpreter(interpreter string) bool {
+	return windowsShellInterpreters[baseName(interpreter)]
+}
+
+// ScriptFilename returns the filename scriptman should give a script named
+// recipe when it will be run by interpreter, modelled on `just`'s
+// shebang.rs: cmd needs a ".bat" extension and PowerShell needs ".ps1" to
+// be recognized, so both are renamed; any other interpreter keeps the
+// script's existing extension, signalled by returning "".
+func ScriptFilename(recipe, interpreter string) string {
+	switch baseName(interpreter) {
+	case "cmd", "cmd.exe":
+		return recipe + ".bat"
+	case "powershell", "powershell.exe", "pwsh", "pwsh.exe":
+		return recipe + ".ps1"
+	default:
+		return ""
+	}
+}
+
+// IncludeShebangLine reports whether scriptman should preserve a leading
+// "#!" line when it writes a script to disk. Neither cmd nor PowerShell
+// understands "#!" - cmd treats it as invalid syntax - so it must be
+// stripped for them even when scriptman itself is running on a POSIX host;
+// on Windows nothing honors a shebang line at all, regardless of interpreter.
+func IncludeShebangLine(interpreter string) bool {
+	if IsWindows {
+		return false
+	}
+	return !IsWindowsShellInterpreter(interpreter)
+}
+
+// MakeShebangCommand builds the argv scriptman should exec to run scriptPath
+// under interpreter. interpreterArgs are the interpreter's own arguments
+// (e.g. from a policy rule's "Args" directive or a metadata block's
+// "interpreter-args" key - see InterpreterChoice.Args) and are placed
+// before scriptPath; scriptArgs are the caller's own arguments to the
+// script (os.Args[1:] of the wrapper invocation) and are placed after. It
+// is the platform-agnostic analogue of what the kernel does for a "#!"
+// line: on POSIX, interpreter interpreterArgs... scriptPath scriptArgs...
+// is exactly what the kernel would run; cmd and PowerShell have no kernel
+// shebang support at all and need their own flags to accept a script path
+// directly, so scriptman must invoke them the same way on every host.
+func MakeShebangCommand(interpreter string, interpreterArgs []string, scriptPath string, scriptArgs []string) (string, []string) {
+	switch baseName(interpreter) {
+	case "cmd", "cmd.exe":
+		argv := append([]string{interpreter, "/C"}, interpreterArgs...)
+		argv = append(argv, scriptPath)
+		return interpreter, append(argv, scriptArgs...)
+	case "powershell", "powershell.exe", "pwsh", "pwsh.exe":
+		argv := append([]string{interpreter}, interpreterArgs...)
+		argv = append(argv, "-File", scriptPath)
+		return interpreter, append(argv, scriptArgs...)
+	default:
+		argv := append([]string{interpreter}, interpreterArgs...)
+		argv = append(argv, scriptPath)
+		return interpreter, append(argv, scriptArgs...)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !windows
+
+package platform
+
+// IsWindows is true when scriptman itself is running on Windows, where no
+// interpreter honors a "#!" line.
+const IsWindows = false
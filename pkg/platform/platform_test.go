@@ -0,0 +1,63 @@
+package platform
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestMakeShebangCommand tests that interpreter args are placed before
+// scriptPath and script args are placed after, for every supported
+// interpreter family.
+func TestMakeShebangCommand(t *testing.T) {
+	tests := []struct {
+		name            string
+		interpreter     string
+		interpreterArgs []string
+		scriptPath      string
+		scriptArgs      []string
+		wantArgv        []string
+	}{
+		{
+			name:        "PosixNoArgs",
+			interpreter: "/usr/bin/python3",
+			scriptPath:  "/home/user/.scriptman/scripts/hello.py",
+			wantArgv:    []string{"/usr/bin/python3", "/home/user/.scriptman/scripts/hello.py"},
+		},
+		{
+			name:            "PosixWithInterpreterAndScriptArgs",
+			interpreter:     "ruby",
+			interpreterArgs: []string{"--enable=frozen-string-literal"},
+			scriptPath:      "script.rb",
+			scriptArgs:      []string{"--verbose", "input.txt"},
+			wantArgv:        []string{"ruby", "--enable=frozen-string-literal", "script.rb", "--verbose", "input.txt"},
+		},
+		{
+			name:            "CmdExe",
+			interpreter:     "cmd.exe",
+			interpreterArgs: []string{"/V:ON"},
+			scriptPath:      "script.bat",
+			scriptArgs:      []string{"arg1"},
+			wantArgv:        []string{"cmd.exe", "/C", "/V:ON", "script.bat", "arg1"},
+		},
+		{
+			name:            "PowerShell",
+			interpreter:     "pwsh",
+			interpreterArgs: []string{"-NoProfile"},
+			scriptPath:      "script.ps1",
+			scriptArgs:      []string{"arg1"},
+			wantArgv:        []string{"pwsh", "-NoProfile", "-File", "script.ps1", "arg1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmdPath, argv := MakeShebangCommand(tt.interpreter, tt.interpreterArgs, tt.scriptPath, tt.scriptArgs)
+			if cmdPath != tt.interpreter {
+				t.Errorf("MakeShebangCommand() cmdPath = %q, want %q", cmdPath, tt.interpreter)
+			}
+			if !reflect.DeepEqual(argv, tt.wantArgv) {
+				t.Errorf("MakeShebangCommand() argv = %v, want %v", argv, tt.wantArgv)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LockfileSchemaVersion is the current lockfile schema version.
+const LockfileSchemaVersion = 1
+
+// Lockfile records the exact resolved state of every pinned install so that
+// a toolchain can be reproduced across machines with `scriptman install --frozen`.
+type Lockfile struct {
+	SchemaVersion int                   `json:"schema_version"`
+	Scripts       map[string]*LockEntry `json:"scripts"`
+}
+
+// LockEntry is the pinned state of a single installed script.
+type LockEntry struct {
+	Repo               string   `json:"repo"`
+	SourceType         string   `json:"source_type,omitempty"`
+	SourcePath         string   `json:"source_path"`
+	Commit             string   `json:"commit"`
+	ContentHash        string   `json:"content_hash"`
+	Interpreter        string   `json:"interpreter"`
+	InterpreterVersion string   `json:"interpreter_version,omitempty"`
+	Args               []string `json:"args,omitempty"`
+	WrapperMode        string   `json:"wrapper_mode,omitempty"`
+	IsWorktree         bool     `json:"is_worktree,omitempty"`
+}
+
+// LoadLockfile reads the lockfile from disk. Returns an empty lockfile if the
+// file doesn't exist.
+func LoadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Lockfile{
+			SchemaVersion: LockfileSchemaVersion,
+			Scripts:       make(map[string]*LockEntry),
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	var lock Lockfile
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile: %w", err)
+	}
+
+	// Defensive check: ensure Scripts map is not nil.
+	if lock.Scripts == nil {
+		lock.Scripts = make(map[string]*LockEntry)
+	}
+
+	return &lock, nil
+}
+
+// Save writes the lockfile to disk.
+func (l *Lockfile) Save(path string) error {
+	// Ensure parent directory exists.
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create lockfile directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal lockfile: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+
+	return nil
+}
+
+// Add adds or updates a lock entry.
+func (l *Lockfile) Add(name string, entry *LockEntry) {
+	if l.Scripts == nil {
+		l.Scripts = make(map[string]*LockEntry)
+	}
+	l.Scripts[name] = entry
+}
+
+// Remove removes a lock entry.
+func (l *Lockfile) Remove(name string) {
+	delete(l.Scripts, name)
+}
+
+// Get retrieves a lock entry.
+func (l *Lockfile) Get(name string) (*LockEntry, error) {
+	entry, exists := l.Scripts[name]
+	if !exists {
+		return nil, fmt.Errorf("script '%s' not found in lockfile", name)
+	}
+	return entry, nil
+}
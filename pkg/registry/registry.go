@@ -26,6 +26,13 @@ type Script struct {
 	WrapperPath string    `json:"wrapper_path"`
 	InstalledAt time.Time `json:"installed_at"`
 	Version     string    `json:"version,omitempty"`
+	Commit      string    `json:"commit,omitempty"`
+	SourceType  string    `json:"source_type,omitempty"`
+	VerifyKeyID string    `json:"verify_key_id,omitempty"`
+	Signature   string    `json:"signature,omitempty"`
+	RootDir     string    `json:"root_dir,omitempty"`
+	WrapperMode string    `json:"wrapper_mode,omitempty"`
+	Args        []string  `json:"args,omitempty"`
 }
 
 // Load reads the registry from disk. Returns an empty registry if file doesn't exist.
@@ -0,0 +1,68 @@
+package registry
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistryLoadSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.json")
+
+	reg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load(nonexistent) error: %v", err)
+	}
+	if len(reg.Scripts) != 0 {
+		t.Fatalf("expected empty registry, got %d scripts", len(reg.Scripts))
+	}
+
+	reg.Add("foo", &Script{Repo: "owner/foo", Interpreter: "bash", Args: []string{"-x"}})
+	if err := reg.Save(path); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load(existing) error: %v", err)
+	}
+	script, err := reloaded.Get("foo")
+	if err != nil {
+		t.Fatalf("Get(foo) error: %v", err)
+	}
+	if script.Repo != "owner/foo" || script.Interpreter != "bash" {
+		t.Errorf("got %+v, want Repo=owner/foo Interpreter=bash", script)
+	}
+	if len(script.Args) != 1 || script.Args[0] != "-x" {
+		t.Errorf("Args = %v, want [-x]", script.Args)
+	}
+}
+
+func TestRegistryAddRemoveExists(t *testing.T) {
+	reg := &Registry{Scripts: make(map[string]*Script)}
+
+	if reg.Exists("foo") {
+		t.Fatalf("Exists(foo) = true before Add")
+	}
+
+	reg.Add("foo", &Script{Repo: "owner/foo"})
+	if !reg.Exists("foo") {
+		t.Fatalf("Exists(foo) = false after Add")
+	}
+
+	if err := reg.Remove("foo"); err != nil {
+		t.Fatalf("Remove(foo) error: %v", err)
+	}
+	if reg.Exists("foo") {
+		t.Fatalf("Exists(foo) = true after Remove")
+	}
+	if err := reg.Remove("foo"); err == nil {
+		t.Fatalf("Remove(foo) expected error for already-removed script")
+	}
+}
+
+func TestRegistryGetMissing(t *testing.T) {
+	reg := &Registry{Scripts: make(map[string]*Script)}
+	if _, err := reg.Get("missing"); err == nil {
+		t.Fatalf("Get(missing) expected error")
+	}
+}
@@ -0,0 +1,124 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// giteaCommit represents a Gitea commit.
+type giteaCommit struct {
+	SHA string `json:"sha"`
+}
+
+// giteaRelease represents a Gitea release.
+type giteaRelease struct {
+	TagName string `json:"tag_name"`
+}
+
+// GiteaSource fetches scripts from a Gitea instance via its REST API.
+type GiteaSource struct {
+	Host string
+	Repo string // owner/repo
+}
+
+// NewGiteaSource creates a Source backed by a Gitea instance.
+func NewGiteaSource(host, repo string) *GiteaSource {
+	return &GiteaSource{Host: host, Repo: repo}
+}
+
+// Type implements Source.
+func (s *GiteaSource) Type() string {
+	return "gitea"
+}
+
+// FetchScript implements Source.
+// If ref is empty, fetches from the repository's default branch.
+func (s *GiteaSource) FetchScript(path, ref string) (*FetchResult, error) {
+	fetchURL := fmt.Sprintf("https://%s/api/v1/repos/%s/raw/%s", s.Host, s.Repo, path)
+	if ref != "" {
+		fetchURL += "?ref=" + ref
+	}
+
+	// #nosec G107 -- Fetching from a user-specified Gitea instance is the core feature of this tool.
+	resp, err := http.Get(fetchURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch script: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch script: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script content: %w", err)
+	}
+
+	commit, err := s.GetCommit(ref)
+	if err != nil {
+		commit = ""
+	}
+
+	return &FetchResult{Content: data, Tag: ref, Commit: commit}, nil
+}
+
+// GetCommit implements Source.
+func (s *GiteaSource) GetCommit(ref string) (string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	apiURL := fmt.Sprintf("https://%s/api/v1/repos/%s/commits/%s", s.Host, s.Repo, ref)
+
+	// #nosec G107 -- Fetching from the Gitea API is the core feature of this tool.
+	resp, err := http.Get(apiURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to query commit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query commit: HTTP %d", resp.StatusCode)
+	}
+
+	var commit giteaCommit
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return "", fmt.Errorf("failed to parse commit response: %w", err)
+	}
+
+	return commit.SHA, nil
+}
+
+// CloneURL implements Source.
+func (s *GiteaSource) CloneURL() (string, bool) {
+	return fmt.Sprintf("https://%s/%s.git", s.Host, s.Repo), true
+}
+
+// GetLatestRelease implements Source.
+func (s *GiteaSource) GetLatestRelease() (string, error) {
+	apiURL := fmt.Sprintf("https://%s/api/v1/repos/%s/releases/latest", s.Host, s.Repo)
+
+	// #nosec G107 -- Fetching from the Gitea API is the core feature of this tool.
+	resp, err := http.Get(apiURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to query releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query releases: HTTP %d", resp.StatusCode)
+	}
+
+	var release giteaRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("failed to parse release response: %w", err)
+	}
+
+	return release.TagName, nil
+}
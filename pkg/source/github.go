@@ -0,0 +1,132 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// githubRelease represents a GitHub release.
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+}
+
+// githubCommit represents a GitHub commit.
+type githubCommit struct {
+	SHA string `json:"sha"`
+}
+
+// GitHubSource fetches scripts from a GitHub repository via the raw content
+// CDN and resolves releases/commits via the GitHub REST API.
+type GitHubSource struct {
+	Repo string // owner/repo
+}
+
+// NewGitHubSource creates a Source backed by GitHub.
+func NewGitHubSource(repo string) *GitHubSource {
+	return &GitHubSource{Repo: repo}
+}
+
+// Type implements Source.
+func (s *GitHubSource) Type() string {
+	return "github"
+}
+
+// FetchScript implements Source.
+// If ref is empty, fetches from the main branch.
+func (s *GitHubSource) FetchScript(path, ref string) (*FetchResult, error) {
+	if ref == "" {
+		ref = "main"
+	}
+
+	url := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s", s.Repo, ref, path)
+
+	// #nosec G107 -- Fetching from user-specified GitHub URLs is the core feature of this tool.
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch script: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch script: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script content: %w", err)
+	}
+
+	// Resolve the commit SHA for this ref; non-fatal if it fails.
+	commit, err := s.GetCommit(ref)
+	if err != nil {
+		commit = ""
+	}
+
+	tag := ref
+	if tag == "main" {
+		tag = ""
+	}
+
+	return &FetchResult{
+		Content: data,
+		Tag:     tag,
+		Commit:  commit,
+	}, nil
+}
+
+// GetCommit implements Source.
+func (s *GitHubSource) GetCommit(ref string) (string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/commits/%s", s.Repo, ref)
+
+	// #nosec G107 -- Fetching from GitHub API is the core feature of this tool.
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to query commit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query commit: HTTP %d", resp.StatusCode)
+	}
+
+	var commit githubCommit
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return "", fmt.Errorf("failed to parse commit response: %w", err)
+	}
+
+	return commit.SHA, nil
+}
+
+// CloneURL implements Source.
+func (s *GitHubSource) CloneURL() (string, bool) {
+	return fmt.Sprintf("https://github.com/%s.git", s.Repo), true
+}
+
+// GetLatestRelease implements Source.
+// Returns an empty string if no releases exist.
+func (s *GitHubSource) GetLatestRelease() (string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", s.Repo)
+
+	// #nosec G107 -- Fetching from GitHub API is the core feature of this tool.
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to query releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query releases: HTTP %d", resp.StatusCode)
+	}
+
+	var release githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("failed to parse release response: %w", err)
+	}
+
+	return release.TagName, nil
+}
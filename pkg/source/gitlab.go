@@ -0,0 +1,132 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// gitlabCommit represents a GitLab commit.
+type gitlabCommit struct {
+	ID string `json:"id"`
+}
+
+// gitlabRelease represents a GitLab release.
+type gitlabRelease struct {
+	TagName string `json:"tag_name"`
+}
+
+// GitLabSource fetches scripts from a GitLab project (gitlab.com or a
+// self-hosted instance) via its public raw-file URLs and REST API.
+type GitLabSource struct {
+	Host string
+	Repo string // owner/repo (project path)
+}
+
+// NewGitLabSource creates a Source backed by a GitLab instance.
+func NewGitLabSource(host, repo string) *GitLabSource {
+	return &GitLabSource{Host: host, Repo: repo}
+}
+
+// Type implements Source.
+func (s *GitLabSource) Type() string {
+	return "gitlab"
+}
+
+// FetchScript implements Source.
+// If ref is empty, fetches from the project's default branch (HEAD).
+func (s *GitLabSource) FetchScript(path, ref string) (*FetchResult, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	fetchURL := fmt.Sprintf("https://%s/%s/-/raw/%s/%s", s.Host, s.Repo, ref, path)
+
+	// #nosec G107 -- Fetching from a user-specified GitLab URL is the core feature of this tool.
+	resp, err := http.Get(fetchURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch script: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch script: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script content: %w", err)
+	}
+
+	commit, err := s.GetCommit(ref)
+	if err != nil {
+		commit = ""
+	}
+
+	tag := ref
+	if tag == "HEAD" {
+		tag = ""
+	}
+
+	return &FetchResult{Content: data, Tag: tag, Commit: commit}, nil
+}
+
+// GetCommit implements Source.
+func (s *GitLabSource) GetCommit(ref string) (string, error) {
+	apiURL := fmt.Sprintf("https://%s/api/v4/projects/%s/repository/commits/%s", s.Host, url.QueryEscape(s.Repo), url.PathEscape(ref))
+
+	// #nosec G107 -- Fetching from the GitLab API is the core feature of this tool.
+	resp, err := http.Get(apiURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to query commit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query commit: HTTP %d", resp.StatusCode)
+	}
+
+	var commit gitlabCommit
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return "", fmt.Errorf("failed to parse commit response: %w", err)
+	}
+
+	return commit.ID, nil
+}
+
+// CloneURL implements Source.
+func (s *GitLabSource) CloneURL() (string, bool) {
+	return fmt.Sprintf("https://%s/%s.git", s.Host, s.Repo), true
+}
+
+// GetLatestRelease implements Source.
+func (s *GitLabSource) GetLatestRelease() (string, error) {
+	apiURL := fmt.Sprintf("https://%s/api/v4/projects/%s/releases", s.Host, url.QueryEscape(s.Repo))
+
+	// #nosec G107 -- Fetching from the GitLab API is the core feature of this tool.
+	resp, err := http.Get(apiURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to query releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query releases: HTTP %d", resp.StatusCode)
+	}
+
+	var releases []gitlabRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return "", fmt.Errorf("failed to parse release response: %w", err)
+	}
+	if len(releases) == 0 {
+		return "", nil
+	}
+
+	// GitLab returns releases ordered newest-first by default.
+	return releases[0].TagName, nil
+}
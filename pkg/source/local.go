@@ -0,0 +1,50 @@
+package source
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LocalSource reads a script from a local file:// path, for air-gapped
+// setups and local mirrors. It has no notion of refs, commits, or releases.
+type LocalSource struct {
+	Root string
+}
+
+// NewLocalSource creates a Source backed by a local directory or file.
+func NewLocalSource(root string) *LocalSource {
+	return &LocalSource{Root: root}
+}
+
+// Type implements Source.
+func (s *LocalSource) Type() string {
+	return "file"
+}
+
+// FetchScript implements Source. ref is ignored: the local filesystem has no
+// notion of refs.
+func (s *LocalSource) FetchScript(path, ref string) (*FetchResult, error) {
+	fullPath := filepath.Join(s.Root, path)
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local script %s: %w", fullPath, err)
+	}
+
+	return &FetchResult{Content: data}, nil
+}
+
+// GetCommit implements Source. Local paths have no commit concept.
+func (s *LocalSource) GetCommit(ref string) (string, error) {
+	return "", nil
+}
+
+// GetLatestRelease implements Source. Local paths have no release concept.
+func (s *LocalSource) GetLatestRelease() (string, error) {
+	return "", nil
+}
+
+// CloneURL implements Source. Local paths are copied directly, not cloned.
+func (s *LocalSource) CloneURL() (string, bool) {
+	return "", false
+}
@@ -0,0 +1,60 @@
+package source
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// RawSource fetches a script from a generic HTTPS (or HTTP) URL. It has no
+// notion of refs, commits, or releases: the URL is the whole identity.
+type RawSource struct {
+	URL string
+}
+
+// NewRawSource creates a Source backed by a single raw URL.
+func NewRawSource(url string) *RawSource {
+	return &RawSource{URL: url}
+}
+
+// Type implements Source.
+func (s *RawSource) Type() string {
+	return "raw"
+}
+
+// FetchScript implements Source. path and ref are ignored: the URL given at
+// construction is fetched as-is.
+func (s *RawSource) FetchScript(path, ref string) (*FetchResult, error) {
+	// #nosec G107 -- Fetching from a user-specified URL is the core feature of this tool.
+	resp, err := http.Get(s.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch script: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch script: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script content: %w", err)
+	}
+
+	return &FetchResult{Content: data}, nil
+}
+
+// GetCommit implements Source. Raw URLs have no commit concept.
+func (s *RawSource) GetCommit(ref string) (string, error) {
+	return "", nil
+}
+
+// GetLatestRelease implements Source. Raw URLs have no release concept.
+func (s *RawSource) GetLatestRelease() (string, error) {
+	return "", nil
+}
+
+// CloneURL implements Source. Raw URLs cannot be cloned.
+func (s *RawSource) CloneURL() (string, bool) {
+	return "", false
+}
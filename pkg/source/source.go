@@ -0,0 +1,129 @@
+// Package source abstracts over the different places a script can be
+// fetched from: GitHub, GitLab, Gitea, a generic HTTPS raw URL, or a local
+// file:// path. It replaces the GitHub-only fetcher that pkg/install used
+// to depend on directly.
+package source
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FetchResult contains the fetched script content and metadata.
+type FetchResult struct {
+	Content []byte
+	Tag     string // The ref fetched at (tag, branch, or commit SHA), empty if fetched from the default branch.
+	Commit  string // Commit SHA at time of fetch, empty if the backend has none.
+}
+
+// Source fetches scripts and resolves refs from a single backend.
+type Source interface {
+	// Type is the short, registry-persisted name of this backend
+	// (e.g. "github", "gitlab", "gitea", "raw", "file").
+	Type() string
+
+	// FetchScript downloads the script at path for the given ref. If ref
+	// is empty, the backend's default branch is used.
+	FetchScript(path, ref string) (*FetchResult, error)
+
+	// GetLatestRelease returns the latest release tag, or "" if the
+	// backend has no notion of releases.
+	GetLatestRelease() (string, error)
+
+	// GetCommit resolves ref to a commit SHA, or "" if the backend has
+	// no notion of commits.
+	GetCommit(ref string) (string, error)
+
+	// CloneURL returns a URL the `git` CLI can clone, and whether this
+	// backend supports clone-based (worktree) fetching at all.
+	CloneURL() (string, bool)
+}
+
+// Detect picks the right Source for a REPO argument and returns it along
+// with the backend-normalized repo identifier (owner/repo, or the raw/local
+// path verbatim).
+//
+// Recognized forms:
+//
+//	owner/repo                      -> GitHub
+//	github.com/owner/repo           -> GitHub
+//	gitlab.com/owner/repo           -> GitLab
+//	gitlab.example.com/owner/repo    -> self-hosted GitLab
+//	gitea.example.com/owner/repo     -> Gitea
+//	https://raw.example.com/foo.sh  -> generic HTTPS raw URL
+//	file:///path/to/script           -> local filesystem
+func Detect(repoArg string) (Source, string, error) {
+	switch {
+	case strings.HasPrefix(repoArg, "file://"):
+		path := strings.TrimPrefix(repoArg, "file://")
+		return NewLocalSource(path), path, nil
+
+	case strings.HasPrefix(repoArg, "https://") || strings.HasPrefix(repoArg, "http://"):
+		u, err := url.Parse(repoArg)
+		if err != nil {
+			return nil, "", fmt.Errorf("malformed source URL %q: %w", repoArg, err)
+		}
+		switch {
+		case hasHostLabel(u.Host, "gitlab"):
+			repo := strings.Trim(u.Path, "/")
+			return NewGitLabSource(u.Host, repo), repo, nil
+		case hasHostLabel(u.Host, "gitea"):
+			repo := strings.Trim(u.Path, "/")
+			return NewGiteaSource(u.Host, repo), repo, nil
+		case u.Host == "github.com":
+			repo := strings.Trim(u.Path, "/")
+			return NewGitHubSource(repo), repo, nil
+		default:
+			return NewRawSource(repoArg), repoArg, nil
+		}
+
+	case strings.HasPrefix(repoArg, "gitlab.com/"):
+		repo := strings.TrimPrefix(repoArg, "gitlab.com/")
+		return NewGitLabSource("gitlab.com", repo), repo, nil
+
+	default:
+		if host, repo, ok := strings.Cut(repoArg, "/"); ok {
+			switch {
+			case hasHostLabel(host, "gitlab"):
+				return NewGitLabSource(host, repo), repo, nil
+			case hasHostLabel(host, "gitea"):
+				return NewGiteaSource(host, repo), repo, nil
+			}
+		}
+
+		repo := strings.TrimPrefix(strings.TrimPrefix(repoArg, "https://"), "github.com/")
+		return NewGitHubSource(repo), repo, nil
+	}
+}
+
+// hasHostLabel reports whether host has label as one of its dot-separated
+// components (e.g. "gitea.example.com" and "git.gitea.internal" do, but
+// "giteaorg.example.com" - a substring match, not a label match - does
+// not). Used to recognize self-hosted GitLab/Gitea instances by hostname
+// without misrouting a GitHub owner/repo whose name merely contains
+// "gitlab"/"gitea".
+func hasHostLabel(host, label string) bool {
+	for _, part := range strings.Split(host, ".") {
+		if part == label {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveScript saves script content to a file with the given permissions.
+func SaveScript(content []byte, destPath string, perm os.FileMode) error {
+	// #nosec G301 -- Standard directory permissions (0755) for script storage directory.
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create script directory: %w", err)
+	}
+
+	if err := os.WriteFile(destPath, content, perm); err != nil {
+		return fmt.Errorf("failed to write script file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package source
+
+import "testing"
+
+// TestDetect tests Source backend routing, including the owner/repo forms
+// that must NOT be misrouted to Gitea/GitLab just because they contain
+// "gitea"/"gitlab" as a substring of the owner or repo name.
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		repoArg  string
+		wantType string
+		wantRepo string
+		wantErr  bool
+	}{
+		{
+			name:     "PlainOwnerRepo",
+			repoArg:  "owner/repo",
+			wantType: "github",
+			wantRepo: "owner/repo",
+		},
+		{
+			name:     "GitHubDotCom",
+			repoArg:  "github.com/owner/repo",
+			wantType: "github",
+			wantRepo: "owner/repo",
+		},
+		{
+			name:     "GitLabDotCom",
+			repoArg:  "gitlab.com/owner/repo",
+			wantType: "gitlab",
+			wantRepo: "owner/repo",
+		},
+		{
+			name:     "SelfHostedGitea",
+			repoArg:  "gitea.example.com/owner/repo",
+			wantType: "gitea",
+			wantRepo: "owner/repo",
+		},
+		{
+			name:     "SelfHostedGitLab",
+			repoArg:  "gitlab.mycompany.com/owner/repo",
+			wantType: "gitlab",
+			wantRepo: "owner/repo",
+		},
+		{
+			name:     "OwnerNameContainingGiteaIsNotMisrouted",
+			repoArg:  "giteaorg/tool",
+			wantType: "github",
+			wantRepo: "giteaorg/tool",
+		},
+		{
+			name:     "OwnerNameContainingGitlabIsNotMisrouted",
+			repoArg:  "gitlabfan/tool",
+			wantType: "github",
+			wantRepo: "gitlabfan/tool",
+		},
+		{
+			name:     "HTTPSGitLabURL",
+			repoArg:  "https://gitlab.com/owner/repo",
+			wantType: "gitlab",
+			wantRepo: "owner/repo",
+		},
+		{
+			name:     "HTTPSGiteaURL",
+			repoArg:  "https://gitea.example.com/owner/repo",
+			wantType: "gitea",
+			wantRepo: "owner/repo",
+		},
+		{
+			name:     "HTTPSRawURL",
+			repoArg:  "https://raw.example.com/foo.sh",
+			wantType: "raw",
+			wantRepo: "https://raw.example.com/foo.sh",
+		},
+		{
+			name:     "FileURL",
+			repoArg:  "file:///srv/mirror",
+			wantType: "file",
+			wantRepo: "/srv/mirror",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, repo, err := Detect(tt.repoArg)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Detect(%q) expected error, got nil", tt.repoArg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Detect(%q) unexpected error: %v", tt.repoArg, err)
+			}
+			if src.Type() != tt.wantType {
+				t.Errorf("Detect(%q) Type() = %q, want %q", tt.repoArg, src.Type(), tt.wantType)
+			}
+			if repo != tt.wantRepo {
+				t.Errorf("Detect(%q) repo = %q, want %q", tt.repoArg, repo, tt.wantRepo)
+			}
+		})
+	}
+}
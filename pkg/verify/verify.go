@@ -0,0 +1,130 @@
+// Package verify checks a detached signature over fetched script content
+// before it is trusted enough to install. Supports minisign-style Ed25519
+// signatures only; SSH allowed_signers-style verification is not supported.
+package verify
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Key is a trusted Ed25519 public key, identified by its minisign key ID.
+type Key struct {
+	ID        string // 8-byte key ID, hex-encoded
+	PublicKey ed25519.PublicKey
+}
+
+// Result records what verification established, so the installer can
+// persist it alongside the installed script.
+type Result struct {
+	KeyID     string
+	Signature string // base64-encoded raw signature bytes
+}
+
+// Verify checks a minisign-style detached signature over content against
+// the given trusted keys. It returns the verifying key's ID on success.
+//
+// Critical invariant for callers: Verify must run, and succeed, before the
+// script content is ever written to disk.
+func Verify(content []byte, sigData []byte, keys []Key) (*Result, error) {
+	sigKeyID, sig, err := parseSignature(sigData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature: %w", err)
+	}
+
+	for _, key := range keys {
+		if key.ID != sigKeyID {
+			continue
+		}
+		if !ed25519.Verify(key.PublicKey, content, sig) {
+			return nil, fmt.Errorf("signature verification failed for key %s", key.ID)
+		}
+		return &Result{
+			KeyID:     key.ID,
+			Signature: base64.StdEncoding.EncodeToString(sig),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no trusted key matches signing key %s", sigKeyID)
+}
+
+// LoadTrustedKeys reads a newline-delimited list of minisign public keys
+// from ~/.config/scriptman/trusted_keys (or a path given via --key). Blank
+// lines and lines starting with '#' are ignored.
+func LoadTrustedKeys(path string) ([]Key, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no trusted keys file at %s; add one or pass --key", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trusted keys file: %w", err)
+	}
+
+	var keys []Key
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "untrusted comment:") {
+			continue
+		}
+		key, err := ParsePublicKey(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key in %s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// ParsePublicKey decodes a single base64-encoded minisign public key:
+// 2 bytes signature algorithm ("Ed"), 8 bytes key ID, 32 bytes Ed25519 key.
+func ParsePublicKey(encoded string) (Key, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to decode base64 public key: %w", err)
+	}
+	if len(raw) != 2+8+32 {
+		return Key{}, fmt.Errorf("unexpected public key length %d (want 42)", len(raw))
+	}
+	if string(raw[:2]) != "Ed" {
+		return Key{}, fmt.Errorf("unsupported signature algorithm %q (only Ed25519 is supported)", raw[:2])
+	}
+
+	return Key{
+		ID:        fmt.Sprintf("%x", raw[2:10]),
+		PublicKey: ed25519.PublicKey(raw[10:42]),
+	}, nil
+}
+
+// parseSignature decodes a minisign signature file's base64 payload line:
+// 2 bytes signature algorithm, 8 bytes key ID, 64 bytes Ed25519 signature.
+func parseSignature(sigData []byte) (keyID string, sig []byte, err error) {
+	var encoded string
+	for _, line := range strings.Split(string(sigData), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "untrusted comment:") || strings.HasPrefix(line, "trusted comment:") {
+			continue
+		}
+		encoded = line
+		break
+	}
+	if encoded == "" {
+		return "", nil, fmt.Errorf("signature file has no payload line")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode base64 signature: %w", err)
+	}
+	if len(raw) != 2+8+64 {
+		return "", nil, fmt.Errorf("unexpected signature length %d (want 74)", len(raw))
+	}
+	if string(raw[:2]) != "Ed" {
+		return "", nil, fmt.Errorf("unsupported signature algorithm %q (only Ed25519 is supported)", raw[:2])
+	}
+
+	return fmt.Sprintf("%x", raw[2:10]), raw[10:74], nil
+}
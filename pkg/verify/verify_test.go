@@ -0,0 +1,88 @@
+package verify
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"testing"
+)
+
+// minisignEncode builds a minisign-style base64 blob: 2-byte algorithm tag,
+// 8-byte key ID, then payload.
+func minisignEncode(keyID [8]byte, payload []byte) string {
+	raw := append([]byte("Ed"), keyID[:]...)
+	raw = append(raw, payload...)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func testKeyID() [8]byte {
+	return [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey error: %v", err)
+	}
+	keyID := testKeyID()
+	encoded := minisignEncode(keyID, pub)
+
+	key, err := ParsePublicKey(encoded)
+	if err != nil {
+		t.Fatalf("ParsePublicKey error: %v", err)
+	}
+	wantID := fmt.Sprintf("%x", keyID)
+	if key.ID != wantID {
+		t.Errorf("key.ID = %q, want %q", key.ID, wantID)
+	}
+	if !key.PublicKey.Equal(pub) {
+		t.Errorf("key.PublicKey = %v, want %v", key.PublicKey, pub)
+	}
+}
+
+func TestParsePublicKeyRejectsWrongLength(t *testing.T) {
+	if _, err := ParsePublicKey(base64.StdEncoding.EncodeToString([]byte("too short"))); err == nil {
+		t.Fatalf("expected error for short key")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey error: %v", err)
+	}
+	keyID := testKeyID()
+	content := []byte("#!/bin/sh\necho hello\n")
+	sig := ed25519.Sign(priv, content)
+	sigData := []byte(minisignEncode(keyID, sig))
+
+	keys := []Key{{ID: fmt.Sprintf("%x", keyID), PublicKey: pub}}
+
+	t.Run("ValidSignatureKnownKey", func(t *testing.T) {
+		result, err := Verify(content, sigData, keys)
+		if err != nil {
+			t.Fatalf("Verify error: %v", err)
+		}
+		if result.KeyID != keys[0].ID {
+			t.Errorf("result.KeyID = %q, want %q", result.KeyID, keys[0].ID)
+		}
+	})
+
+	t.Run("TamperedContent", func(t *testing.T) {
+		if _, err := Verify([]byte("tampered"), sigData, keys); err == nil {
+			t.Fatalf("expected verification failure for tampered content")
+		}
+	})
+
+	t.Run("UntrustedKey", func(t *testing.T) {
+		if _, err := Verify(content, sigData, nil); err == nil {
+			t.Fatalf("expected error when no trusted key matches")
+		}
+	})
+}
+
+func TestParseSignatureRejectsEmptyPayload(t *testing.T) {
+	if _, _, err := parseSignature([]byte("untrusted comment: foo\n\n")); err == nil {
+		t.Fatalf("expected error for signature file with no payload line")
+	}
+}
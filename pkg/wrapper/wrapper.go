@@ -4,12 +4,30 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // CreateWrapper creates a shell script wrapper that executes the given script.
 func CreateWrapper(interpreterPath, scriptPath, wrapperPath string) error {
-	// Generate shell script with baked-in paths.
-	shellScript := fmt.Sprintf("#!/bin/sh\nexec %s %s \"$@\"\n", interpreterPath, scriptPath)
+	return CreateWrapperInDir(interpreterPath, nil, scriptPath, wrapperPath, "")
+}
+
+// CreateWrapperInDir creates a shell script wrapper that executes the given
+// script. interpreterArgs, if non-empty, are the interpreter's own
+// arguments (e.g. from a policy rule's "Args" directive or a metadata
+// block's "interpreter-args" key) and are inserted before scriptPath. If
+// rootDir is non-empty, the wrapper cds into it before exec so that scripts
+// fetched as part of a multi-file worktree (see pkg/git) can resolve
+// relative imports and sibling data files.
+func CreateWrapperInDir(interpreterPath string, interpreterArgs []string, scriptPath, wrapperPath, rootDir string) error {
+	command := strings.TrimSpace(strings.Join(append([]string{interpreterPath}, interpreterArgs...), " ") + " " + scriptPath)
+
+	var shellScript string
+	if rootDir == "" {
+		shellScript = fmt.Sprintf("#!/bin/sh\nexec %s \"$@\"\n", command)
+	} else {
+		shellScript = fmt.Sprintf("#!/bin/sh\ncd %s || exit 1\nexec %s \"$@\"\n", rootDir, command)
+	}
 
 	// Ensure parent directory exists.
 	if err := os.MkdirAll(filepath.Dir(wrapperPath), 0755); err != nil {
@@ -24,6 +42,28 @@ func CreateWrapper(interpreterPath, scriptPath, wrapperPath string) error {
 	return nil
 }
 
+// CreateExecWrapper points wrapperPath at the scriptman executable itself,
+// via a hard link (falling back to a symlink if the link crosses a
+// filesystem boundary). Invoking the wrapper under its own basename re-enters
+// scriptman in runner mode, which execs the resolved interpreter+script
+// directly in place - no shell indirection, correct PID/signal handling.
+func CreateExecWrapper(selfPath, wrapperPath string) error {
+	// Ensure parent directory exists.
+	if err := os.MkdirAll(filepath.Dir(wrapperPath), 0755); err != nil {
+		return fmt.Errorf("failed to create wrapper directory: %w", err)
+	}
+
+	_ = os.Remove(wrapperPath)
+
+	if err := os.Link(selfPath, wrapperPath); err != nil {
+		if symErr := os.Symlink(selfPath, wrapperPath); symErr != nil {
+			return fmt.Errorf("failed to link wrapper: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // Remove removes a wrapper script.
 func Remove(wrapperPath string) error {
 	if err := os.Remove(wrapperPath); err != nil && !os.IsNotExist(err) {
@@ -0,0 +1,93 @@
+package wrapper
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCreateWrapperInDir(t *testing.T) {
+	tests := []struct {
+		name            string
+		interpreterArgs []string
+		rootDir         string
+		wantContains    []string
+	}{
+		{
+			name:         "NoArgsNoRootDir",
+			wantContains: []string{"#!/bin/sh\n", "exec /usr/bin/bash /tmp/script.sh \"$@\"\n"},
+		},
+		{
+			name:            "WithInterpreterArgs",
+			interpreterArgs: []string{"-x", "-e"},
+			wantContains:    []string{"exec /usr/bin/bash -x -e /tmp/script.sh \"$@\"\n"},
+		},
+		{
+			name:         "WithRootDir",
+			rootDir:      "/tmp/work",
+			wantContains: []string{"cd /tmp/work || exit 1\n", "exec /usr/bin/bash /tmp/script.sh \"$@\"\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapperPath := filepath.Join(t.TempDir(), "wrapper")
+			if err := CreateWrapperInDir("/usr/bin/bash", tt.interpreterArgs, "/tmp/script.sh", wrapperPath, tt.rootDir); err != nil {
+				t.Fatalf("CreateWrapperInDir error: %v", err)
+			}
+
+			data, err := os.ReadFile(wrapperPath)
+			if err != nil {
+				t.Fatalf("ReadFile error: %v", err)
+			}
+			content := string(data)
+			for _, want := range tt.wantContains {
+				if !strings.Contains(content, want) {
+					t.Errorf("wrapper content %q does not contain %q", content, want)
+				}
+			}
+
+			info, err := os.Stat(wrapperPath)
+			if err != nil {
+				t.Fatalf("Stat error: %v", err)
+			}
+			if info.Mode().Perm()&0111 == 0 {
+				t.Errorf("wrapper is not executable: mode %v", info.Mode())
+			}
+		})
+	}
+}
+
+func TestCreateWrapperDelegatesToCreateWrapperInDir(t *testing.T) {
+	wrapperPath := filepath.Join(t.TempDir(), "wrapper")
+	if err := CreateWrapper("/usr/bin/python3", "/tmp/script.py", wrapperPath); err != nil {
+		t.Fatalf("CreateWrapper error: %v", err)
+	}
+
+	data, err := os.ReadFile(wrapperPath)
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	if !strings.Contains(string(data), "exec /usr/bin/python3 /tmp/script.py \"$@\"\n") {
+		t.Errorf("wrapper content = %q, missing expected exec line", string(data))
+	}
+}
+
+func TestRemove(t *testing.T) {
+	wrapperPath := filepath.Join(t.TempDir(), "wrapper")
+	if err := CreateWrapper("/usr/bin/bash", "/tmp/script.sh", wrapperPath); err != nil {
+		t.Fatalf("CreateWrapper error: %v", err)
+	}
+	if err := Remove(wrapperPath); err != nil {
+		t.Fatalf("Remove error: %v", err)
+	}
+	if _, err := os.Stat(wrapperPath); !os.IsNotExist(err) {
+		t.Errorf("expected wrapper to be removed, stat err = %v", err)
+	}
+
+	// Removing an already-missing wrapper is not an error.
+	if err := Remove(wrapperPath); err != nil {
+		t.Errorf("Remove(missing) error: %v", err)
+	}
+}